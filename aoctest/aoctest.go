@@ -0,0 +1,171 @@
+// Package aoctest provides a golden file test harness for the puzzle examples
+// kept in the testdata directories of the puzzle packages. The examples are
+// loaded with LoadExamples, every solver registered for an example's day is
+// run against the example input and the answers are compared with a golden
+// file in the solver.Answers format.
+// Running the tests with the -update flag rewrites the golden files with the
+// current answers.
+package aoctest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the current answers")
+
+// Example represents an example input given in a puzzle description.
+type Example struct {
+	Day int
+	// Name distinguishes between several examples for the same day. It is
+	// empty for a day's primary example.
+	Name  string
+	Input string
+}
+
+// GoldenPath returns the location of the golden file for an example within
+// dir, e.g. dir/day09.golden or dir/day09.larger.golden for a named example.
+func GoldenPath(dir string, e Example) string {
+	if e.Name == "" {
+		return filepath.Join(dir, fmt.Sprintf("day%02d.golden", e.Day))
+	}
+	return filepath.Join(dir, fmt.Sprintf("day%02d.%s.golden", e.Day, e.Name))
+}
+
+// LoadExamples returns an Example for every file matching pattern (see
+// filepath.Glob) ordered by day and then by name. A day's primary example must be stored in a file named
+// dayNN-example.txt and a named example in a file named dayNN-NAME-example.txt.
+// An error is returned if the pattern is malformed, a file cannot be read or
+// its name is not in one of these forms.
+func LoadExamples(pattern string) ([]Example, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var examples []Example
+	for _, path := range paths {
+		e, err := exampleFromName(filepath.Base(path))
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		e.Input = string(data)
+		examples = append(examples, e)
+	}
+	sort.Slice(examples, func(i, j int) bool {
+		if examples[i].Day != examples[j].Day {
+			return examples[i].Day < examples[j].Day
+		}
+		return examples[i].Name < examples[j].Name
+	})
+	return examples, nil
+}
+
+// exampleFromName returns an Example with the day and name given by the name
+// of an example file, e.g. day09-larger-example.txt.
+func exampleFromName(base string) (Example, error) {
+	const prefix, suffix = "day", "-example.txt"
+	if !strings.HasPrefix(base, prefix) || !strings.HasSuffix(base, suffix) {
+		return Example{}, fmt.Errorf(`example file name must be in the form "dayNN-example.txt" or "dayNN-NAME-example.txt" (got %s)`, base)
+	}
+	rest := strings.TrimSuffix(strings.TrimPrefix(base, prefix), suffix)
+	dayText, name, _ := strings.Cut(rest, "-")
+	day, err := strconv.Atoi(dayText)
+	if err != nil {
+		return Example{}, fmt.Errorf("day in example file name %s must be a valid integer: %w", base, err)
+	}
+	return Example{Day: day, Name: name}, nil
+}
+
+// Answers runs every solver registered in reg for the example's day against
+// the example input and returns their answers. An error is returned if no
+// solvers are registered for the day or if a solver returns an error.
+func Answers(reg *solver.Registry, e Example) (solver.Answers, error) {
+	solvers := reg.Day(e.Day)
+	if len(solvers) == 0 {
+		return nil, fmt.Errorf("no solvers are registered for day %d", e.Day)
+	}
+	got := solver.Answers{}
+	for _, s := range solvers {
+		answer, err := s.Solve(strings.NewReader(e.Input))
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", s.Part(), err)
+		}
+		got[s.Part()] = answer.String()
+	}
+	return got, nil
+}
+
+// Check runs the solvers for an example and compares their answers with the
+// example's golden file in dir. It returns a diff (-want +got) that is empty
+// when the answers match. When update is true the golden file is rewritten
+// with the current answers instead. An error is returned if a solver fails or
+// the golden file cannot be read or written.
+func Check(reg *solver.Registry, e Example, dir string, update bool) (string, error) {
+	got, err := Answers(reg, e)
+	if err != nil {
+		return "", err
+	}
+	path := GoldenPath(dir, e)
+	if update {
+		var buf bytes.Buffer
+		if _, err := got.WriteTo(&buf); err != nil {
+			return "", err
+		}
+		return "", os.WriteFile(path, buf.Bytes(), 0o644)
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("golden file %s does not exist, run the tests with -update to create it", path)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	want, err := solver.ReadAnswers(f)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return cmp.Diff(want, got), nil
+}
+
+// RunExamples runs a subtest for every example that checks the answers of the
+// solvers in reg against the example's golden file in dir.
+func RunExamples(t *testing.T, reg *solver.Registry, examples []Example, dir string) {
+	t.Helper()
+	if len(examples) == 0 {
+		t.Fatal("no examples were given")
+	}
+	for _, e := range examples {
+		e := e
+		name := fmt.Sprintf("day%02d", e.Day)
+		if e.Name != "" {
+			name += "/" + e.Name
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			diff, err := Check(reg, e, dir, *update)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff != "" {
+				t.Errorf("answers do not match %s (-want +got):\n%s", GoldenPath(dir, e), diff)
+			}
+		})
+	}
+}
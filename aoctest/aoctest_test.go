@@ -0,0 +1,162 @@
+package aoctest_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/aoctest"
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func answerLength(input io.Reader) (solver.Answer, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(len(data)), nil
+}
+
+func newRegistry() *solver.Registry {
+	r := solver.NewRegistry()
+	r.Register(solver.New(4, 1, answerLength))
+	r.Register(solver.New(4, 2, func(input io.Reader) (solver.Answer, error) {
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.StringAnswer(strings.ToUpper(string(data))), nil
+	}))
+	return r
+}
+
+func TestGoldenPath(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input aoctest.Example
+		want  string
+	}{
+		"Unnamed example uses the day": {
+			input: aoctest.Example{Day: 4},
+			want:  filepath.Join("testdata", "day04.golden"),
+		},
+		"Named example includes the name": {
+			input: aoctest.Example{Day: 9, Name: "larger"},
+			want:  filepath.Join("testdata", "day09.larger.golden"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := aoctest.GoldenPath("testdata", tc.input)
+			if tc.want != got {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadExamplesReadsDayAndNameFromFileName(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"day04-example.txt":        "2-4,6-8\n",
+		"day09-larger-example.txt": "R 5\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := aoctest.LoadExamples(filepath.Join(dir, "day*-example.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []aoctest.Example{
+		{Day: 4, Input: "2-4,6-8\n"},
+		{Day: 9, Name: "larger", Input: "R 5\n"},
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestLoadExamplesWithInvalidFileNameReturnsError(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]string{
+		"File name without day prefix returns error":   "example.txt",
+		"File name with non-numeric day returns error": "dayfour-example.txt",
+	}
+	for name, file := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, file), nil, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := aoctest.LoadExamples(filepath.Join(dir, "*.txt"))
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestCheckWithUpdateWritesGoldenFileThatMatches(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	r := newRegistry()
+	e := aoctest.Example{Day: 4, Input: "abc"}
+	if _, err := aoctest.Check(r, e, dir, true); err != nil {
+		t.Fatal(err)
+	}
+	golden, err := os.ReadFile(aoctest.GoldenPath(dir, e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "part 1: 3\npart 2: ABC\n"
+	if want != string(golden) {
+		t.Errorf("want golden file %q, got %q", want, golden)
+	}
+	diff, err := aoctest.Check(r, e, dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("want no diff, got:\n%s", diff)
+	}
+}
+
+func TestCheckReturnsDiffWhenAnswersDoNotMatchGoldenFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	e := aoctest.Example{Day: 4, Input: "abc"}
+	err := os.WriteFile(aoctest.GoldenPath(dir, e), []byte("part 1: 3\npart 2: XYZ\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := aoctest.Check(newRegistry(), e, dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == "" {
+		t.Error("want a diff but did not get one")
+	}
+}
+
+func TestCheckErrorCases(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]aoctest.Example{
+		"Missing golden file returns error":            {Day: 4, Input: "abc"},
+		"Day without registered solvers returns error": {Day: 5, Input: "abc"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aoctest.Check(newRegistry(), tc, t.TempDir(), false)
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package aoctest_test
+
+import (
+	"testing"
+
+	"github.com/aculclasure/aoc2022/aoctest"
+	_ "github.com/aculclasure/aoc2022/puzzles"
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+// examplesPattern matches the example inputs in the testdata directories of
+// the puzzle packages.
+const examplesPattern = "../*/testdata/day*-example.txt"
+
+func TestExamples(t *testing.T) {
+	t.Parallel()
+	examples, err := aoctest.LoadExamples(examplesPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	days := make(map[int]bool)
+	for _, e := range examples {
+		days[e.Day] = true
+	}
+	for _, s := range solver.All() {
+		if !days[s.Day()] {
+			t.Errorf("want an example for day %d in %s", s.Day(), examplesPattern)
+		}
+	}
+	aoctest.RunExamples(t, solver.DefaultRegistry, examples, "testdata")
+}
@@ -0,0 +1,48 @@
+package aoctest_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func TestGeneratedInputsAreReproducibleAndSolvable(t *testing.T) {
+	t.Parallel()
+	const size = 200
+	for day := 1; day <= 11; day++ {
+		day := day
+		generate, ok := solver.LookupGenerator(day)
+		if !ok {
+			t.Errorf("want a generator registered for day %d", day)
+			continue
+		}
+		t.Run(fmt.Sprintf("day%02d", day), func(t *testing.T) {
+			t.Parallel()
+			var first, second, other bytes.Buffer
+			if err := generate(&first, rand.New(rand.NewSource(1)), size); err != nil {
+				t.Fatal(err)
+			}
+			if err := generate(&second, rand.New(rand.NewSource(1)), size); err != nil {
+				t.Fatal(err)
+			}
+			if err := generate(&other, rand.New(rand.NewSource(2)), size); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				t.Error("want the same seed to generate the same input")
+			}
+			if bytes.Equal(first.Bytes(), other.Bytes()) {
+				t.Error("want different seeds to generate different inputs")
+			}
+			for _, s := range solver.Day(day) {
+				res := solver.Run(s, first.Bytes())
+				if res.Err != nil {
+					t.Errorf("part %d: %s", s.Part(), res.Err)
+				}
+			}
+		})
+	}
+}
@@ -2,12 +2,13 @@
 package camp
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 type CleaningAssignment struct {
@@ -45,40 +46,49 @@ func OverlapExists(pair CleaningPair) bool {
 func PairFromInputLine(input string) (CleaningPair, error) {
 	assignmentFields := strings.Split(input, ",")
 	if len(assignmentFields) != 2 {
-		return CleaningPair{}, fmt.Errorf("input must contain 2 assignments separated by a comma (got %d assignments for input %s)", len(assignmentFields), input)
+		return CleaningPair{}, textio.FieldError(1, input, fmt.Errorf("input must contain 2 assignments separated by a comma (got %d assignments for input %s)", len(assignmentFields), input))
 	}
 
 	var assignments []CleaningAssignment
+	column := 1
 	for _, asg := range assignmentFields {
 		sectorFields := strings.Split(asg, "-")
 		if len(sectorFields) != 2 {
-			return CleaningPair{}, fmt.Errorf("assignment must be in the form start-end (got %s)", asg)
+			return CleaningPair{}, textio.FieldError(column, asg, fmt.Errorf("assignment must be in the form start-end (got %s)", asg))
 		}
 		start, err := strconv.Atoi(sectorFields[0])
 		if err != nil {
-			return CleaningPair{}, err
+			return CleaningPair{}, textio.FieldError(column, sectorFields[0], err)
 		}
 		end, err := strconv.Atoi(sectorFields[1])
 		if err != nil {
-			return CleaningPair{}, err
+			return CleaningPair{}, textio.FieldError(column+len(sectorFields[0])+1, sectorFields[1], err)
 		}
 		assignments = append(assignments, CleaningAssignment{StartSector: start, EndSector: end})
+		column += len(asg) + 1
 	}
 
 	return CleaningPair{First: assignments[0], Second: assignments[1]}, nil
 }
 
-func GetFullyOverlappingPairs(schedules io.Reader) ([]CleaningPair, error) {
+// GetFullyOverlappingPairs accepts an io.Reader pointing to line-separated
+// cleaning pairs and returns the pairs where one assignment fully contains the
+// other. A line that is not a pair of "start-end" section ranges is reported
+// as a *textio.ParseError.
+func GetFullyOverlappingPairs(schedules io.Reader, opts ...textio.Option) ([]CleaningPair, error) {
 	if schedules == nil {
 		return nil, errors.New("schedules must be a non-nil argument")
 	}
 
 	var fullyOverlapping []CleaningPair
-	sc := bufio.NewScanner(schedules)
+	sc := textio.NewScanner(schedules, opts...)
 	for sc.Scan() {
 		pair, err := PairFromInputLine(sc.Text())
 		if err != nil {
-			return nil, err
+			if err := sc.Skip(err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
 		if FullOverlapExists(pair) {
@@ -93,17 +103,22 @@ func GetFullyOverlappingPairs(schedules io.Reader) ([]CleaningPair, error) {
 	return fullyOverlapping, nil
 }
 
-func GetOverlappingPairs(schedules io.Reader) ([]CleaningPair, error) {
+// GetOverlappingPairs behaves like GetFullyOverlappingPairs except that it
+// returns the pairs whose assignments overlap at all.
+func GetOverlappingPairs(schedules io.Reader, opts ...textio.Option) ([]CleaningPair, error) {
 	if schedules == nil {
 		return nil, errors.New("schedules must be a non-nil argument")
 	}
 
 	var overlapping []CleaningPair
-	sc := bufio.NewScanner(schedules)
+	sc := textio.NewScanner(schedules, opts...)
 	for sc.Scan() {
 		pair, err := PairFromInputLine(sc.Text())
 		if err != nil {
-			return nil, err
+			if err := sc.Skip(err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
 		if OverlapExists(pair) {
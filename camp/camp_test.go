@@ -1,10 +1,12 @@
 package camp_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/aculclasure/aoc2022/camp"
+	"github.com/aculclasure/aoc2022/textio"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -278,3 +280,16 @@ func TestGetOverlappingPairs(t *testing.T) {
 		t.Error(cmp.Diff(want, got))
 	}
 }
+
+func TestGetOverlappingPairsReturnsParseErrorForInvalidLine(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader("2-4,6-8\n2-3,4-x\n")
+	_, err := camp.GetOverlappingPairs(input)
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError, got %v", err)
+	}
+	if pe.Line != 2 || pe.Column != 7 || pe.Text != "x" {
+		t.Errorf("want line 2, column 7, text x, got line %d, column %d, text %s", pe.Line, pe.Column, pe.Text)
+	}
+}
@@ -0,0 +1,49 @@
+package camp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// GenerateAssignments writes numPairs random pairs of cleaning assignments to
+// w, one "start-end,start-end" pair per line with sectors between 1 and 99. An
+// error is returned if numPairs is smaller than 1 or if there is a problem
+// writing to w.
+func GenerateAssignments(w io.Writer, rng *rand.Rand, numPairs int) error {
+	if numPairs < 1 {
+		return fmt.Errorf("number of pairs must be at least 1 (got %d)", numPairs)
+	}
+	const maxSector = 99
+	assignment := func() (int, int) {
+		start := 1 + rng.Intn(maxSector)
+		return start, start + rng.Intn(maxSector-start+1)
+	}
+	bw := bufio.NewWriter(w)
+	for i := 0; i < numPairs; i++ {
+		firstStart, firstEnd := assignment()
+		secondStart, secondEnd := assignment()
+		fmt.Fprintf(bw, "%d-%d,%d-%d\n", firstStart, firstEnd, secondStart, secondEnd)
+	}
+	return bw.Flush()
+}
+
+// GenerateForest writes a random square forest of tree heights (0-9) with
+// size rows and size columns to w. An error is returned if size is smaller
+// than 1 or if there is a problem writing to w.
+func GenerateForest(w io.Writer, rng *rand.Rand, size int) error {
+	if size < 1 {
+		return fmt.Errorf("forest size must be at least 1 (got %d)", size)
+	}
+	bw := bufio.NewWriter(w)
+	row := make([]byte, size+1)
+	row[size] = '\n'
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			row[c] = byte('0' + rng.Intn(10))
+		}
+		bw.Write(row)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,63 @@
+package camp
+
+import (
+	"context"
+	"io"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func init() {
+	solver.Register(solver.New(4, 1, solveDay4Part1))
+	solver.Register(solver.New(4, 2, solveDay4Part2))
+	solver.Register(solver.NewPhased(8, 1, readTrees, solveDay8Part1))
+	solver.Register(solver.NewPhasedContext(8, 2, readTrees, solveDay8Part2))
+	solver.RegisterGenerator(4, GenerateAssignments)
+	solver.RegisterGenerator(8, GenerateForest)
+}
+
+func solveDay4Part1(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	pairs, err := GetFullyOverlappingPairs(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(len(pairs)), nil
+}
+
+func solveDay4Part2(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	pairs, err := GetOverlappingPairs(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(len(pairs)), nil
+}
+
+// readTrees reads the forest from the day 8 puzzle input. An error is returned
+// if the input is empty or if a tree height is not a digit.
+func readTrees(input io.Reader) (*Forest, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return nil, err
+	}
+	return ReadForest(input)
+}
+
+func solveDay8Part1(forest *Forest) (solver.Answer, error) {
+	return solver.IntAnswer(len(forest.VisibleTrees())), nil
+}
+
+func solveDay8Part2(ctx context.Context, forest *Forest) (solver.Answer, error) {
+	score, err := forest.MaxScenicScoreContext(ctx)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(score), nil
+}
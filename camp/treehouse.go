@@ -1,11 +1,16 @@
 package camp
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 // TreesFromBytes accepts a slice of bytes that is intended to come from a file
@@ -15,32 +20,101 @@ func TreesFromBytes(input []byte) []string {
 	return strings.Fields(strings.TrimSpace(string(input)))
 }
 
+// Forest represents a grid of tree heights, one digit per tree.
+type Forest struct {
+	ds.Grid[int]
+}
+
+// ReadForest accepts an io.Reader pointing to line-separated tree height data
+// with one digit per tree and returns the Forest it describes. An error is
+// returned if a tree height is not a digit, if the rows are not all the same
+// length, or if there is a problem reading the input. Errors in the input are
+// returned as a *textio.ParseError giving the position of the offending tree.
+func ReadForest(input io.Reader, opts ...textio.Option) (*Forest, error) {
+	grid, err := ds.ReadGrid(input, treeHeight, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Forest{Grid: *grid}, nil
+}
+
+// forestFromRows accepts a slice of strings representing a grid of tree height
+// data and returns the Forest it describes. An error is returned if the grid
+// contains any non-numerical data.
+func forestFromRows(trees []string) (*Forest, error) {
+	forest, err := ReadForest(strings.NewReader(strings.Join(trees, "\n")))
+	if err != nil {
+		return nil, fmt.Errorf("got error %w, check that your trees input only contains integers", err)
+	}
+	return forest, nil
+}
+
+// treeHeight accepts a character of tree height data and returns the height
+// of the tree. An error is returned if the character is not a digit.
+func treeHeight(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, fmt.Errorf("tree height must be a digit (got %q)", r)
+	}
+	return int(r - '0'), nil
+}
+
+// coordStrings accepts a slice of positions and returns them as coordinate
+// strings in the form "R C".
+func coordStrings(points []ds.Point) []string {
+	var coords []string
+	for _, p := range points {
+		coords = append(coords, strconv.Itoa(p.Row)+" "+strconv.Itoa(p.Col))
+	}
+	return coords
+}
+
 // MaxScenicStore accepts a slice of strings representing a grid of tree height
 // data and returns the highest scenic score value for a single tree within the
 // grid. An error is returned if the grid contains any non-numerical data.
 func MaxScenicScore(trees []string) (int, error) {
-	type scoreResult struct {
-		score int
-		err   error
+	return MaxScenicScoreContext(context.Background(), trees)
+}
+
+// MaxScenicScoreContext behaves like MaxScenicScore but checks ctx before
+// scoring each row of the grid and returns ctx.Err() if ctx has been
+// cancelled.
+func MaxScenicScoreContext(ctx context.Context, trees []string) (int, error) {
+	forest, err := forestFromRows(trees)
+	if err != nil {
+		return 0, err
 	}
+	return forest.MaxScenicScoreContext(ctx)
+}
+
+// MaxScenicScore returns the highest scenic score of a single tree in the
+// forest, or -1 for an empty forest.
+func (f *Forest) MaxScenicScore() int {
+	score, _ := f.MaxScenicScoreContext(context.Background())
+	return score
+}
+
+// MaxScenicScoreContext behaves like MaxScenicScore but scores the rows of the
+// forest concurrently, checking ctx before scoring each row, and returns
+// ctx.Err() if ctx has been cancelled.
+func (f *Forest) MaxScenicScoreContext(ctx context.Context) (int, error) {
 	var wg sync.WaitGroup
-	results := make(chan scoreResult)
-	for i := range trees {
+	// The results channel is buffered so that the remaining rows do not
+	// block forever once ctx has been cancelled.
+	results := make(chan int, f.Rows())
+	for i := 0; i < f.Rows(); i++ {
 		wg.Add(1)
 		go func(rowIdx int) {
 			defer wg.Done()
+			if ctx.Err() != nil {
+				return
+			}
 			maxScore := -1
-			for colIdx := range trees[rowIdx] {
-				score, err := ScenicScore(trees, strconv.Itoa(rowIdx)+" "+strconv.Itoa(colIdx))
-				if err != nil {
-					results <- scoreResult{err: err}
-					return
-				}
-				if score > maxScore {
+			for colIdx := 0; colIdx < f.Cols(); colIdx++ {
+				if score := f.scenicScore(ds.Point{Row: rowIdx, Col: colIdx}); score > maxScore {
 					maxScore = score
 				}
 			}
-			results <- scoreResult{score: maxScore}
+			results <- maxScore
 		}(i)
 	}
 	go func() {
@@ -48,28 +122,35 @@ func MaxScenicScore(trees []string) (int, error) {
 		wg.Wait()
 	}()
 	maxScore := -1
-	for res := range results {
-		if res.err != nil {
-			return 0, res.err
-		}
-		if res.score > maxScore {
-			maxScore = res.score
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case score, ok := <-results:
+			if !ok {
+				if err := ctx.Err(); err != nil {
+					return 0, err
+				}
+				return maxScore, nil
+			}
+			if score > maxScore {
+				maxScore = score
+			}
 		}
 	}
-	return maxScore, nil
 }
 
 // ScenicScore accepts a slice of strings representing a grid of tree height
-// data and a coordinate and returns the scenic score for the tree at that given
-// coordinate. An error is returned if the grid contains invalid data (e.g. non
-// integer characters) or if the coordinate is invalid.
+// data and a coordinate in the form "r c" and returns the scenic score for the
+// tree at that given coordinate. An error is returned if the grid contains
+// invalid data (e.g. non integer characters) or if the coordinate is invalid.
+// Only the row and column through the coordinate are parsed, so scoring many
+// trees is cheaper with Forest.ScenicScore on a Forest read once.
 func ScenicScore(trees []string, coord string) (int, error) {
-	numRows := len(trees)
-	if numRows == 0 {
+	if len(trees) == 0 {
 		return 0, errors.New("trees must be non-empty slice")
 	}
-	numCols := len(trees[0])
-	if numCols == 0 {
+	if len(trees[0]) == 0 {
 		return 0, errors.New("trees must contain at least 1 row and column of height data")
 	}
 	coordFields := strings.Fields(coord)
@@ -84,123 +165,89 @@ func ScenicScore(trees []string, coord string) (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("got error %s, is the column in your coordinate a valid integer?", err)
 	}
-	if row < 0 || row >= numRows {
-		return 0, fmt.Errorf("row must be a value from 0-%d (got %d)", numRows-1, row)
+	if row < 0 || row >= len(trees) {
+		return 0, fmt.Errorf("row must be a value from 0-%d (got %d)", len(trees)-1, row)
 	}
-	if col < 0 || col >= numCols {
-		return 0, fmt.Errorf("col must be a value from 0-%d (got %d)", numCols-1, col)
+	if col < 0 || col >= len(trees[0]) {
+		return 0, fmt.Errorf("col must be a value from 0-%d (got %d)", len(trees[0])-1, col)
 	}
-	if row == 0 || row == numRows-1 || col == 0 || col == numCols-1 { // Row or column is on an edge
-		return 0, nil
+	column := make([]string, len(trees))
+	for r, line := range trees {
+		if col >= len(line) {
+			return 0, fmt.Errorf("row %d must have a column %d (got %d columns)", r, col, len(line))
+		}
+		column[r] = line[col : col+1]
 	}
-	startHeight, err := strconv.Atoi(string(trees[row][col]))
+	across, err := forestFromRows(trees[row : row+1])
 	if err != nil {
-		return 0, fmt.Errorf("got error %s, check that your trees input only contains integers", err)
+		return 0, err
 	}
-	score := 1
-	viewingDistance := 0
-	for c := col; c+1 < numCols; c++ {
-		viewingDistance++
-		currentHeight, err := strconv.Atoi(string(trees[row][c+1]))
-		if err != nil {
-			return 0, fmt.Errorf("got error %s, check that your trees input only contains integers", err)
-		}
-		if currentHeight >= startHeight {
-			break
-		}
+	down, err := forestFromRows(column)
+	if err != nil {
+		return 0, err
 	}
-	score *= viewingDistance
-	viewingDistance = 0
-	for c := col; c-1 >= 0; c-- {
-		viewingDistance++
-		currentHeight, err := strconv.Atoi(string(trees[row][c-1]))
-		if err != nil {
-			return 0, fmt.Errorf("got error %s, check that your trees input only contains integers", err)
-		}
-		if currentHeight >= startHeight {
-			break
-		}
+	rowPos, colPos := ds.Point{Col: col}, ds.Point{Row: row}
+	return across.viewingDistance(rowPos, ds.Left) * across.viewingDistance(rowPos, ds.Right) *
+		down.viewingDistance(colPos, ds.Up) * down.viewingDistance(colPos, ds.Down), nil
+}
+
+// ScenicScore accepts the position of a tree in the forest and returns its
+// scenic score: the product of its viewing distances up, right, down and left.
+// A tree on the edge of the forest has a score of 0. An error is returned if
+// the position is outside of the forest.
+func (f *Forest) ScenicScore(p ds.Point) (int, error) {
+	if p.Row < 0 || p.Row >= f.Rows() {
+		return 0, fmt.Errorf("row must be a value from 0-%d (got %d)", f.Rows()-1, p.Row)
 	}
-	score *= viewingDistance
-	viewingDistance = 0
-	for r := row; r+1 < numRows; r++ {
-		viewingDistance++
-		currentHeight, err := strconv.Atoi(string(trees[r+1][col]))
-		if err != nil {
-			return 0, fmt.Errorf("got error %s, check that your trees input only contains integers", err)
-		}
-		if currentHeight >= startHeight {
-			break
-		}
+	if p.Col < 0 || p.Col >= f.Cols() {
+		return 0, fmt.Errorf("col must be a value from 0-%d (got %d)", f.Cols()-1, p.Col)
 	}
-	score *= viewingDistance
-	viewingDistance = 0
-	for r := row; r-1 >= 0; r-- {
-		viewingDistance++
-		currentHeight, err := strconv.Atoi(string(trees[r-1][col]))
-		if err != nil {
-			return 0, fmt.Errorf("got error %s, check that your trees input only contains integers", err)
-		}
-		if currentHeight >= startHeight {
-			break
-		}
+	return f.scenicScore(p), nil
+}
+
+// scenicScore returns the scenic score of the tree at p, which must be inside
+// the forest.
+func (f *Forest) scenicScore(p ds.Point) int {
+	score := 1
+	for _, dir := range ds.Directions4 {
+		score *= f.viewingDistance(p, dir)
 	}
-	score *= viewingDistance
-	return score, nil
+	return score
+}
+
+// viewingDistance returns the number of trees seen from the tree at p when
+// looking in direction dir, up to and including the first tree that is at
+// least as tall.
+func (f *Forest) viewingDistance(p, dir ds.Point) int {
+	startHeight, _ := f.Get(p.Row, p.Col)
+	distance := 0
+	f.Ray(p.Row, p.Col, dir.Row, dir.Col, func(_, _ int, height int) bool {
+		distance++
+		return height < startHeight
+	})
+	return distance
 }
 
 // AllVisibleTrees accepts a slice of strings representing a grid of tree height
 // data and returns a slice of coordinate strings for all trees that are visible
 // from outside the grid. Each coordinate in the returned slice is in the form
 // "R C", where R indicates the row and C indicates the column of a visible tree
-// (e.g. "0 3", "1 4", etc.) An error is returned if the grid contains any
-// non-numerical data.
+// (e.g. "0 3", "1 4", etc.) The coordinates are sorted row by row. An error is
+// returned if the grid contains any non-numerical data.
 func AllVisibleTrees(trees []string) ([]string, error) {
-	type result struct {
-		visTrees []string
-		err      error
-	}
-	var wg sync.WaitGroup
-	results := make(chan result)
-	visibleSet := make(map[string]struct{})
-	wg.Add(4)
-	go func() {
-		defer wg.Done()
-		visFromLeft, err := VisibleFromLeft(trees)
-		results <- result{visTrees: visFromLeft, err: err}
-	}()
-	go func() {
-		defer wg.Done()
-		visFromTop, err := VisibleFromTop(trees)
-		results <- result{visTrees: visFromTop, err: err}
-	}()
-	go func() {
-		defer wg.Done()
-		visFromRight, err := VisibleFromRight(trees)
-		results <- result{visTrees: visFromRight, err: err}
-	}()
-	go func() {
-		defer wg.Done()
-		visFromBtm, err := VisibleFromBottom(trees)
-		results <- result{visTrees: visFromBtm, err: err}
-	}()
-	go func() {
-		defer close(results)
-		wg.Wait()
-	}()
-	for r := range results {
-		if r.err != nil {
-			return nil, r.err
-		}
-		for _, visTreeCoord := range r.visTrees {
-			visibleSet[visTreeCoord] = struct{}{}
+	return visibleFromSide(trees, (*Forest).VisibleTrees)
+}
+
+// VisibleTrees returns the positions of all trees that are visible from
+// outside the forest, sorted row by row.
+func (f *Forest) VisibleTrees() []ds.Point {
+	var visible ds.Set[ds.Point]
+	for _, side := range [][]ds.Point{f.VisibleFromLeft(), f.VisibleFromTop(), f.VisibleFromRight(), f.VisibleFromBottom()} {
+		for _, p := range side {
+			visible.Add(p)
 		}
 	}
-	var visible []string
-	for k := range visibleSet {
-		visible = append(visible, k)
-	}
-	return visible, nil
+	return visible.Sorted(ds.Point.Less)
 }
 
 // VisibleFromLeft accepts a slice of strings representing a grid of tree height
@@ -210,21 +257,7 @@ func AllVisibleTrees(trees []string) ([]string, error) {
 // a visible tree (e.g. "0 3", "1 4", etc.) An error is returned if the grid
 // contains any non-numerical data.
 func VisibleFromLeft(trees []string) ([]string, error) {
-	var visible []string
-	for row := 0; row < len(trees); row++ {
-		maxHeight := -1
-		for col := 0; col < len(trees[row]); col++ {
-			currentHeight, err := strconv.Atoi(string(trees[row][col]))
-			if err != nil {
-				return nil, fmt.Errorf("got error %s, does your trees argument only contain numerical data?", err)
-			}
-			if currentHeight > maxHeight {
-				maxHeight = currentHeight
-				visible = append(visible, strconv.Itoa(row)+" "+strconv.Itoa(col))
-			}
-		}
-	}
-	return visible, nil
+	return visibleFromSide(trees, (*Forest).VisibleFromLeft)
 }
 
 // VisibleFromRight accepts a slice of strings representing a grid of tree height
@@ -234,21 +267,7 @@ func VisibleFromLeft(trees []string) ([]string, error) {
 // a visible tree (e.g. "0 3", "1 4", etc.) An error is returned if the grid
 // contains any non-numerical data.
 func VisibleFromRight(trees []string) ([]string, error) {
-	var visible []string
-	for row := 0; row < len(trees); row++ {
-		maxHeight := -1
-		for col := len(trees[row]) - 1; col >= 0; col-- {
-			currentHeight, err := strconv.Atoi(string(trees[row][col]))
-			if err != nil {
-				return nil, fmt.Errorf("got error %s, does your trees argument only contain numerical data?", err)
-			}
-			if currentHeight > maxHeight {
-				maxHeight = currentHeight
-				visible = append(visible, strconv.Itoa(row)+" "+strconv.Itoa(col))
-			}
-		}
-	}
-	return visible, nil
+	return visibleFromSide(trees, (*Forest).VisibleFromRight)
 }
 
 // VisibleFromTop accepts a slice of strings representing a grid of tree height
@@ -258,25 +277,7 @@ func VisibleFromRight(trees []string) ([]string, error) {
 // column of a visible tree (e.g. "0 3", "1 4", etc.) An error is returned if
 // the grid contains any non-numerical data.
 func VisibleFromTop(trees []string) ([]string, error) {
-	if len(trees) == 0 {
-		return nil, nil
-	}
-	var visible []string
-	numCols := len(trees[0])
-	for col := 0; col < numCols; col++ {
-		maxHeight := -1
-		for row := 0; row < len(trees); row++ {
-			currentHeight, err := strconv.Atoi(string(trees[row][col]))
-			if err != nil {
-				return nil, fmt.Errorf("got error %s, does your trees argument only contain numerical data?", err)
-			}
-			if currentHeight > maxHeight {
-				maxHeight = currentHeight
-				visible = append(visible, strconv.Itoa(row)+" "+strconv.Itoa(col))
-			}
-		}
-	}
-	return visible, nil
+	return visibleFromSide(trees, (*Forest).VisibleFromTop)
 }
 
 // VisibleFromBottom accepts a slice of strings representing a grid of tree height
@@ -286,23 +287,71 @@ func VisibleFromTop(trees []string) ([]string, error) {
 // column of a visible tree (e.g. "0 3", "1 4", etc.) An error is returned if
 // the grid contains any non-numerical data.
 func VisibleFromBottom(trees []string) ([]string, error) {
-	if len(trees) == 0 {
-		return nil, nil
+	return visibleFromSide(trees, (*Forest).VisibleFromBottom)
+}
+
+// visibleFromSide parses trees into a Forest and returns the positions
+// reported by visible as coordinate strings.
+func visibleFromSide(trees []string, visible func(*Forest) []ds.Point) ([]string, error) {
+	forest, err := forestFromRows(trees)
+	if err != nil {
+		return nil, err
 	}
-	var visible []string
-	numCols := len(trees[0])
-	for col := 0; col < numCols; col++ {
-		maxHeight := -1
-		for row := len(trees) - 1; row >= 0; row-- {
-			currentHeight, err := strconv.Atoi(string(trees[row][col]))
-			if err != nil {
-				return nil, fmt.Errorf("got error %s, does your trees argument only contain numerical data?", err)
-			}
-			if currentHeight > maxHeight {
-				maxHeight = currentHeight
-				visible = append(visible, strconv.Itoa(row)+" "+strconv.Itoa(col))
-			}
-		}
+	return coordStrings(visible(forest)), nil
+}
+
+// VisibleFromLeft returns the positions of the trees that are visible from
+// the left side of the forest, row by row.
+func (f *Forest) VisibleFromLeft() []ds.Point {
+	var visible []ds.Point
+	for row := 0; row < f.Rows(); row++ {
+		visible = append(visible, f.lineOfSight(ds.Point{Row: row, Col: -1}, ds.Right)...)
 	}
-	return visible, nil
+	return visible
+}
+
+// VisibleFromRight returns the positions of the trees that are visible from
+// the right side of the forest, row by row.
+func (f *Forest) VisibleFromRight() []ds.Point {
+	var visible []ds.Point
+	for row := 0; row < f.Rows(); row++ {
+		visible = append(visible, f.lineOfSight(ds.Point{Row: row, Col: f.Cols()}, ds.Left)...)
+	}
+	return visible
+}
+
+// VisibleFromTop returns the positions of the trees that are visible from the
+// top side of the forest, column by column.
+func (f *Forest) VisibleFromTop() []ds.Point {
+	var visible []ds.Point
+	for col := 0; col < f.Cols(); col++ {
+		visible = append(visible, f.lineOfSight(ds.Point{Row: -1, Col: col}, ds.Down)...)
+	}
+	return visible
+}
+
+// VisibleFromBottom returns the positions of the trees that are visible from
+// the bottom side of the forest, column by column.
+func (f *Forest) VisibleFromBottom() []ds.Point {
+	var visible []ds.Point
+	for col := 0; col < f.Cols(); col++ {
+		visible = append(visible, f.lineOfSight(ds.Point{Row: f.Rows(), Col: col}, ds.Up)...)
+	}
+	return visible
+}
+
+// lineOfSight looks across the forest from the given position just outside of
+// it in the given direction and returns the positions of the trees that are
+// taller than every tree in front of them, nearest first.
+func (f *Forest) lineOfSight(from, dir ds.Point) []ds.Point {
+	var visible []ds.Point
+	maxHeight := -1
+	f.Ray(from.Row, from.Col, dir.Row, dir.Col, func(row, col int, height int) bool {
+		if height > maxHeight {
+			maxHeight = height
+			visible = append(visible, ds.Point{Row: row, Col: col})
+		}
+		return maxHeight < 9
+	})
+	return visible
 }
@@ -1,9 +1,15 @@
 package camp_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/aculclasure/aoc2022/camp"
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
@@ -57,6 +63,17 @@ func TestMaxScenicScoreWithInvalidTreeGridReturnsError(t *testing.T) {
 		t.Error("expected an error but did not get one")
 	}
 }
+
+func TestMaxScenicScoreContextWithCancelledContextReturnsContextError(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := camp.MaxScenicScoreContext(ctx, validTreeHeights)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want error %v, got %v", context.Canceled, err)
+	}
+}
+
 func TestScenicScore(t *testing.T) {
 	t.Parallel()
 	testCases := map[string]struct {
@@ -232,3 +249,82 @@ func TestVisibleFromBottomWithNonNumericalHeightsDataReturnsError(t *testing.T)
 		t.Error("expected an error but did not get one")
 	}
 }
+
+func TestReadForestReturnsParseErrorForInvalidHeight(t *testing.T) {
+	t.Parallel()
+	_, err := camp.ReadForest(strings.NewReader("303\n2x5\n"))
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError, got %v", err)
+	}
+	if pe.Line != 2 || pe.Column != 2 {
+		t.Errorf("want error at 2:2, got %d:%d", pe.Line, pe.Column)
+	}
+}
+
+func TestForest_TypedAPIs(t *testing.T) {
+	t.Parallel()
+	forest, err := camp.ReadForest(strings.NewReader(strings.Join(validTreeHeights, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	score, err := forest.ScenicScore(ds.Point{Row: 3, Col: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != 8 {
+		t.Errorf("want scenic score 8, got %d", score)
+	}
+	if got := forest.MaxScenicScore(); got != 8 {
+		t.Errorf("want max scenic score 8, got %d", got)
+	}
+	wantLeft := []ds.Point{{Row: 0, Col: 0}, {Row: 0, Col: 3}, {Row: 1, Col: 0}, {Row: 1, Col: 1}, {Row: 2, Col: 0}, {Row: 3, Col: 0}, {Row: 3, Col: 2}, {Row: 3, Col: 4}, {Row: 4, Col: 0}, {Row: 4, Col: 1}, {Row: 4, Col: 3}}
+	if got := forest.VisibleFromLeft(); !cmp.Equal(wantLeft, got) {
+		t.Error(cmp.Diff(wantLeft, got))
+	}
+	visible := forest.VisibleTrees()
+	if len(visible) != 21 {
+		t.Errorf("want 21 visible trees, got %d", len(visible))
+	}
+	for i := 1; i < len(visible); i++ {
+		if !visible[i-1].Less(visible[i]) {
+			t.Errorf("want visible trees sorted row by row, got %v before %v", visible[i-1], visible[i])
+		}
+	}
+}
+
+func TestForest_ScenicScoreOutsideForestReturnsError(t *testing.T) {
+	t.Parallel()
+	forest, err := camp.ReadForest(strings.NewReader(strings.Join(validTreeHeights, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []ds.Point{{Row: -1}, {Col: 5}} {
+		if _, err := forest.ScenicScore(p); err == nil {
+			t.Errorf("expected an error for position %v but did not get one", p)
+		}
+	}
+}
+
+func TestScenicScoreMatchesForestScenicScoreForEveryTree(t *testing.T) {
+	t.Parallel()
+	forest, err := camp.ReadForest(strings.NewReader(strings.Join(validTreeHeights, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for row := 0; row < forest.Rows(); row++ {
+		for col := 0; col < forest.Cols(); col++ {
+			want, err := forest.ScenicScore(ds.Point{Row: row, Col: col})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := camp.ScenicScore(validTreeHeights, fmt.Sprintf("%d %d", row, col))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want != got {
+				t.Errorf("tree %d,%d: want %d, got %d", row, col, want, got)
+			}
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package cargo
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
@@ -9,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 var rgx = regexp.MustCompile(`^move (\d+) from (\d+) to (\d+)$`)
@@ -181,74 +182,167 @@ func GetCrates(line string) []Crate {
 // where QUANTITY is a quantity of items, SRCSTACK is an integer representing
 // the source stack, and DESTSTACK is an integer representing the destination
 // stack and returns a Movement struct with the parsed data. An error is returned
-// if the line cannot be properly parsed into a Movement struct.
+// if the line cannot be properly parsed into a Movement struct. The error holds
+// a *textio.ParseError giving the column of the offending text.
 func MovementFromLine(line string) (Movement, error) {
-	submatches := rgx.FindAllStringSubmatch(line, -1)
+	submatches := rgx.FindStringSubmatchIndex(line)
 	if submatches == nil {
-		return Movement{}, fmt.Errorf("line must be in the form move <qty> from <srcstack> to <deststack> (got %s)", line)
+		return Movement{}, textio.FieldError(1, line, fmt.Errorf("line must be in the form move <qty> from <srcstack> to <deststack> (got %s)", line))
 	}
 
-	qty, err := strconv.Atoi(submatches[0][1])
+	// field returns the integer value of the nth submatch.
+	field := func(n int, name string) (int, error) {
+		start, end := submatches[2*n], submatches[2*n+1]
+		val, err := strconv.Atoi(line[start:end])
+		if err != nil {
+			return 0, textio.FieldError(start+1, line[start:end], fmt.Errorf("%s field in line must be a valid integer (got %s)", name, line[start:end]))
+		}
+		return val, nil
+	}
+	qty, err := field(1, "quantity")
 	if err != nil {
-		return Movement{}, fmt.Errorf("quantity field in line must be a valid integer (got %s)", submatches[0][1])
+		return Movement{}, err
 	}
-	src, err := strconv.Atoi(submatches[0][2])
+	src, err := field(2, "srcstack")
 	if err != nil {
-		return Movement{}, fmt.Errorf("srcstack field in line must be a valid integer (got %s)", submatches[0][2])
+		return Movement{}, err
 	}
-	dest, err := strconv.Atoi(submatches[0][3])
+	dest, err := field(3, "deststack")
 	if err != nil {
-		return Movement{}, fmt.Errorf("deststack field in line must be a valid integer (got %s)", submatches[0][3])
+		return Movement{}, err
 	}
 
 	return Movement{Quantity: qty, SrcStack: src, DestStack: dest}, nil
 }
 
-// LayoutFromData accepts an io.Reader pointing to an initial cargo layout and
-// a series of movements to apply cargo layout and returns the top items from
-// from the stacks in the final cargo layout as a string. An error is returned
-// if there is a problem reading the data or an invalid movement is applied
-// to the layout.
-func LayoutFromData(data io.Reader) (*Layout, error) {
+// MoveEvent describes a movement that was applied to a cargo layout.
+type MoveEvent struct {
+	// Number is the number of movements applied so far, starting at 1.
+	Number   int
+	Movement Movement
+	// Line is the number of the input line holding the movement.
+	Line   int
+	Layout *Layout
+}
+
+// Opt represents a functional option that can be passed in during a call to
+// the LayoutFromData functions.
+type Opt func(*layoutConfig)
+
+// layoutConfig holds the settings applied by the Opts given to the
+// LayoutFromData functions.
+type layoutConfig struct {
+	parseOpts []textio.Option
+	observe   func(MoveEvent)
+}
+
+// WithParseOptions accepts textio options and returns an Opt that applies them
+// when reading the data.
+func WithParseOptions(opts ...textio.Option) Opt {
+	return func(c *layoutConfig) {
+		c.parseOpts = append(c.parseOpts, opts...)
+	}
+}
+
+// WithObserver accepts a function and returns an Opt that makes the
+// LayoutFromData functions call it with a MoveEvent after every applied
+// movement. The observer must not modify the layout.
+func WithObserver(fn func(MoveEvent)) Opt {
+	return func(c *layoutConfig) {
+		c.observe = fn
+	}
+}
+
+// LayoutFromDataWithCrateMover9000 accepts an io.Reader pointing to an initial
+// cargo layout and a series of movements to apply to the cargo layout using
+// the CrateMover 9000 (one crate at a time) and returns the final cargo
+// layout. An error is returned if there is a problem reading the data or an
+// invalid movement is applied to the layout. A movement that is malformed or
+// refers to a missing or empty stack is reported as a *textio.ParseError.
+func LayoutFromDataWithCrateMover9000(data io.Reader, opts ...Opt) (*Layout, error) {
+	return layoutFromData(data, (*Layout).Move, opts...)
+}
+
+// LayoutFromDataWithCrateMover9001 behaves like
+// LayoutFromDataWithCrateMover9000 except that the movements are applied
+// using the CrateMover 9001, which moves multiple crates at once while
+// retaining their order.
+func LayoutFromDataWithCrateMover9001(data io.Reader, opts ...Opt) (*Layout, error) {
+	return layoutFromData(data, (*Layout).MoveWithCrateMover9001, opts...)
+}
+
+// layoutFromData builds a Layout from the given data, applying each movement
+// with the given move function. The data must start with a block drawing the
+// stacks, followed by a blank line and the block of movements.
+func layoutFromData(data io.Reader, move func(*Layout, Movement) error, opts ...Opt) (*Layout, error) {
 	if data == nil {
 		return nil, errors.New("data must be non-nil")
 	}
+	var cfg layoutConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 
-	var (
-		crateRows [][]Crate
-		layout    *Layout
-		err       error
-	)
-	scn := bufio.NewScanner(data)
-	for scn.Scan() {
-		line := scn.Text()
-		switch {
-		case strings.Contains(line, "["):
-			crateRows = append(crateRows, GetCrates(line))
-		case strings.HasPrefix(strings.TrimSpace(line), "1"):
-			numStacks := len(strings.Fields(line))
-			layout, err = NewLayout(numStacks)
-			if err != nil {
-				return nil, fmt.Errorf("got error creating layout: %s", err)
-			}
-			err = layout.InitializeFromCrateRows(crateRows)
-			if err != nil {
-				return nil, fmt.Errorf("got error initializing layout from crate rows: %s", err)
-			}
-		case strings.HasPrefix(line, "move"):
+	blocks := textio.NewBlockScanner(data, cfg.parseOpts...)
+	if !blocks.Scan() {
+		if err := blocks.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("data must contain a drawing of the stacks")
+	}
+	layout, err := layoutFromDrawing(blocks)
+	if err != nil {
+		return nil, err
+	}
+	numMoves := 0
+	for blocks.Scan() {
+		for i, line := range blocks.Block().Lines {
 			mv, err := MovementFromLine(line)
 			if err != nil {
-				return nil, fmt.Errorf("got error creating movement from line %s: %s", line, err)
+				if err := blocks.Skip(i, err); err != nil {
+					return nil, err
+				}
+				continue
 			}
-			err = layout.MoveWithCrateMover9001(mv)
+			err = move(layout, mv)
 			if err != nil {
-				return nil, fmt.Errorf("got error applying movement to layout: %s", err)
+				if err := blocks.Skip(i, fmt.Errorf("got error applying movement to layout: %w", err)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			numMoves++
+			if cfg.observe != nil {
+				cfg.observe(MoveEvent{Number: numMoves, Movement: mv, Line: blocks.Block().LineNum(i), Layout: layout})
 			}
 		}
 	}
-	if err := scn.Err(); err != nil {
+	if err := blocks.Err(); err != nil {
 		return nil, err
 	}
 
 	return layout, nil
 }
+
+// layoutFromDrawing builds a Layout from the current block of blocks, which
+// holds rows of crates followed by the line numbering the stacks.
+func layoutFromDrawing(blocks *textio.BlockScanner) (*Layout, error) {
+	lines := blocks.Block().Lines
+	last := len(lines) - 1
+	if !strings.HasPrefix(strings.TrimSpace(lines[last]), "1") {
+		return nil, blocks.Error(last, errors.New("drawing of the stacks must end with the line numbering the stacks"))
+	}
+	var crateRows [][]Crate
+	for _, line := range lines[:last] {
+		crateRows = append(crateRows, GetCrates(line))
+	}
+	layout, err := NewLayout(len(strings.Fields(lines[last])))
+	if err != nil {
+		return nil, blocks.Error(last, fmt.Errorf("got error creating layout: %w", err))
+	}
+	err = layout.InitializeFromCrateRows(crateRows)
+	if err != nil {
+		return nil, blocks.Error(last, fmt.Errorf("got error initializing layout from crate rows: %w", err))
+	}
+	return layout, nil
+}
@@ -1,11 +1,13 @@
 package cargo_test
 
 import (
+	"errors"
 	"io"
 	"strings"
 	"testing"
 
 	"github.com/aculclasure/aoc2022/cargo"
+	"github.com/aculclasure/aoc2022/textio"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -128,7 +130,7 @@ func TestMovementFromLine(t *testing.T) {
 		},
 		"Multi-digit fields returns a valid movement": {
 			input: "move 100 from 10 to 100",
-			want:  cargo.Movement{Quantity: 100, SrcStack: 10, DestStack: 10},
+			want:  cargo.Movement{Quantity: 100, SrcStack: 10, DestStack: 100},
 		},
 	}
 	for name, tc := range testCases {
@@ -189,7 +191,7 @@ move 4 from 2 to 1
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			layout, err := cargo.LayoutFromData(tc.input)
+			layout, err := cargo.LayoutFromDataWithCrateMover9000(tc.input)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -204,6 +206,52 @@ move 4 from 2 to 1
 	}
 }
 
+func TestLayoutFromDataWithCrateMover9000(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader(`    [D]
+[N] [C]
+[Z] [M] [P]
+ 1   2   3
+
+move 1 from 2 to 1
+move 3 from 1 to 3
+move 2 from 2 to 1
+move 1 from 1 to 2
+`)
+	layout, err := cargo.LayoutFromDataWithCrateMover9000(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "CMZ"
+	got := layout.GetTopItems()
+	if want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestLayoutFromDataWithCrateMover9001(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader(`    [D]
+[N] [C]
+[Z] [M] [P]
+ 1   2   3
+
+move 1 from 2 to 1
+move 3 from 1 to 3
+move 2 from 2 to 1
+move 1 from 1 to 2
+`)
+	layout, err := cargo.LayoutFromDataWithCrateMover9001(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "MCD"
+	got := layout.GetTopItems()
+	if want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
 func TestLayout_AddCrateWithInvalidCrateReturnsError(t *testing.T) {
 	t.Parallel()
 	numStacks := 3
@@ -362,3 +410,48 @@ func TestLayout_GetTopItems(t *testing.T) {
 		})
 	}
 }
+
+func TestLayoutFromDataReturnsParseErrorForInvalidMovement(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader(`[A] [B]
+ 1   2
+
+move 1 from 1 to 2
+move 99999999999999999999 from 1 to 2
+`)
+	_, err := cargo.LayoutFromDataWithCrateMover9000(input)
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError, got %v", err)
+	}
+	if pe.Line != 5 || pe.Column != 6 || pe.Text != "99999999999999999999" {
+		t.Errorf("want line 5, column 6, text 99999999999999999999, got line %d, column %d, text %s", pe.Line, pe.Column, pe.Text)
+	}
+}
+
+func TestLayoutFromDataPassesMoveEventsToObservers(t *testing.T) {
+	t.Parallel()
+	data := "[A]    \n[B] [C]\n 1   2\n\nmove 1 from 1 to 2\nmove 5 from 2 to 1\nmove 2 from 2 to 1\n"
+	var got []cargo.MoveEvent
+	var tops []string
+	observe := cargo.WithObserver(func(e cargo.MoveEvent) {
+		tops = append(tops, e.Layout.GetTopItems())
+		e.Layout = nil
+		got = append(got, e)
+	})
+	_, err := cargo.LayoutFromDataWithCrateMover9000(strings.NewReader(data), cargo.WithParseOptions(textio.Lenient()), observe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []cargo.MoveEvent{
+		{Number: 1, Movement: cargo.Movement{Quantity: 1, SrcStack: 1, DestStack: 2}, Line: 5},
+		{Number: 2, Movement: cargo.Movement{Quantity: 2, SrcStack: 2, DestStack: 1}, Line: 7},
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+	wantTops := []string{"BA", "C"}
+	if !cmp.Equal(wantTops, tops) {
+		t.Error(cmp.Diff(wantTops, tops))
+	}
+}
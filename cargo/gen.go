@@ -0,0 +1,60 @@
+package cargo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// GenerateProcedure writes a random starting layout of nine crate stacks
+// followed by numMoves random movements to w. Every movement is valid for both
+// the CrateMover 9000 and the CrateMover 9001. An error is returned if numMoves
+// is smaller than 1 or if there is a problem writing to w.
+func GenerateProcedure(w io.Writer, rng *rand.Rand, numMoves int) error {
+	if numMoves < 1 {
+		return fmt.Errorf("number of moves must be at least 1 (got %d)", numMoves)
+	}
+	const (
+		numStacks = 9
+		maxHeight = 8
+	)
+	heights := make([]int, numStacks)
+	for i := range heights {
+		heights[i] = 1 + rng.Intn(maxHeight)
+	}
+	bw := bufio.NewWriter(w)
+	for level := maxHeight; level >= 1; level-- {
+		cells := make([]string, numStacks)
+		for i, h := range heights {
+			cells[i] = "   "
+			if h >= level {
+				cells[i] = fmt.Sprintf("[%c]", 'A'+rng.Intn(26))
+			}
+		}
+		if row := strings.TrimRight(strings.Join(cells, " "), " "); row != "" {
+			fmt.Fprintln(bw, row)
+		}
+	}
+	labels := make([]string, numStacks)
+	for i := range labels {
+		labels[i] = fmt.Sprintf(" %d ", i+1)
+	}
+	fmt.Fprintf(bw, "%s\n\n", strings.Join(labels, " "))
+	for i := 0; i < numMoves; i++ {
+		src := rng.Intn(numStacks)
+		for heights[src] == 0 {
+			src = rng.Intn(numStacks)
+		}
+		dest := rng.Intn(numStacks - 1)
+		if dest >= src {
+			dest++
+		}
+		qty := 1 + rng.Intn(heights[src])
+		heights[src] -= qty
+		heights[dest] += qty
+		fmt.Fprintf(bw, "move %d from %d to %d\n", qty, src+1, dest+1)
+	}
+	return bw.Flush()
+}
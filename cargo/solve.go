@@ -0,0 +1,41 @@
+package cargo
+
+import (
+	"errors"
+	"io"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func init() {
+	solver.Register(solver.New(5, 1, solveDay5Part1))
+	solver.Register(solver.New(5, 2, solveDay5Part2))
+	solver.RegisterGenerator(5, GenerateProcedure)
+}
+
+func solveDay5Part1(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return topItems(LayoutFromDataWithCrateMover9000(input))
+}
+
+func solveDay5Part2(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return topItems(LayoutFromDataWithCrateMover9001(input))
+}
+
+// topItems returns the top items of the given layout as an Answer.
+func topItems(layout *Layout, err error) (solver.Answer, error) {
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	if layout == nil {
+		return solver.Answer{}, errors.New("input must contain a cargo layout")
+	}
+	return solver.StringAnswer(layout.GetTopItems()), nil
+}
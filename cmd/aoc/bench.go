@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+// bench implements the "aoc bench" subcommand.
+func (a *app) bench(args []string) error {
+	flags := a.newFlagSet("bench")
+	day := flags.Int("day", 0, "puzzle day to benchmark; benchmarks every day when omitted")
+	part := flags.Int("part", 0, "puzzle part to benchmark; benchmarks both parts when omitted")
+	n := flags.Int("n", 10, "number of iterations to run each solver for")
+	storeDir, name := storeFlags(flags)
+	out := flags.String("out", "", "path to write the results to as JSON")
+	baselinePath := flags.String("baseline", "", "path to a JSON results file to compare the results against")
+	threshold := flags.Float64("threshold", 0.1, "fractional slowdown or allocation growth reported as a regression")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	var baseline []solver.BenchResult
+	if *baselinePath != "" {
+		data, err := os.ReadFile(*baselinePath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &baseline); err != nil {
+			return fmt.Errorf("reading baseline %s: %w", *baselinePath, err)
+		}
+	}
+	solvers, err := a.selectSolvers(*day, *part)
+	if err != nil {
+		return err
+	}
+	store := inputs.NewStore(*storeDir)
+	loaded := make(map[int]*inputs.Input)
+	var results []solver.BenchResult
+	for _, s := range solvers {
+		in, ok := loaded[s.Day()]
+		if !ok {
+			in, err = store.Load(s.Day(), *name)
+			if err != nil {
+				return err
+			}
+			loaded[s.Day()] = in
+		}
+		res, err := solver.Bench(s, in.Data, *n)
+		if err != nil {
+			return fmt.Errorf("day %d, part %d: %s", s.Day(), s.Part(), err)
+		}
+		results = append(results, res)
+	}
+	if *out != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*out, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	if baseline == nil {
+		return printBenchResults(a.stdout, results)
+	}
+	comparisons, err := solver.CompareBench(baseline, results, *threshold)
+	if err != nil {
+		return err
+	}
+	return printBenchComparisons(a.stdout, comparisons)
+}
+
+// printBenchResults writes the benchmark results to w as a table.
+func printBenchResults(w io.Writer, results []solver.BenchResult) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "DAY\tPART\tNS/OP\tALLOCS/OP\tBYTES/OP\t")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t\n", r.Day, r.Part, r.NsPerOp, r.AllocsPerOp, r.BytesPerOp)
+	}
+	return tw.Flush()
+}
+
+// printBenchComparisons writes the benchmark comparisons to w as a table and
+// returns an error if any comparison regressed.
+func printBenchComparisons(w io.Writer, comparisons []solver.BenchComparison) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "DAY\tPART\tOLD NS/OP\tNEW NS/OP\tDELTA\tOLD ALLOCS/OP\tNEW ALLOCS/OP\tDELTA\t\t")
+	numRegressed := 0
+	for _, c := range comparisons {
+		mark := ""
+		if c.Regressed {
+			mark = "REGRESSION"
+			numRegressed++
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%+.1f%%\t%d\t%d\t%+.1f%%\t%s\t\n",
+			c.Current.Day, c.Current.Part,
+			c.Baseline.NsPerOp, c.Current.NsPerOp, c.NsDelta*100,
+			c.Baseline.AllocsPerOp, c.Current.AllocsPerOp, c.AllocsDelta*100,
+			mark)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if numRegressed > 0 {
+		return fmt.Errorf("%d of %d benchmarks regressed", numRegressed, len(comparisons))
+	}
+	return nil
+}
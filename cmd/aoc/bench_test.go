@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBenchWritesResultsAndComparesThemWithBaseline(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n"})
+	out := filepath.Join(t.TempDir(), "bench.json")
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"bench", "-inputs", store.Dir, "-day", "1", "-n", "2", "-out", out}); code != 0 {
+		t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout.String(), "NS/OP") {
+		t.Errorf("want a table of results, got:\n%s", stdout)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []solver.BenchResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatal(err)
+	}
+	var got [][2]int
+	for _, r := range results {
+		got = append(got, [2]int{r.Day, r.Part})
+		if r.Iterations != 2 {
+			t.Errorf("day %d, part %d: want 2 iterations, got %d", r.Day, r.Part, r.Iterations)
+		}
+	}
+	if want := [][2]int{{1, 1}, {1, 2}}; !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+
+	// A baseline claiming no time and no allocations makes every result a
+	// regression.
+	for i := range results {
+		results[i].NsPerOp, results[i].AllocsPerOp = 0, 0
+	}
+	data, err = json.Marshal(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(baseline, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a, stdout, stderr = newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"bench", "-inputs", store.Dir, "-day", "1", "-n", "2", "-baseline", baseline}); code != 1 {
+		t.Fatalf("want exit code 1, got %d", code)
+	}
+	if n := strings.Count(stdout.String(), "REGRESSION"); n != 2 {
+		t.Errorf("want 2 regressions, got:\n%s", stdout)
+	}
+	if want := "aoc bench: 2 of 2 benchmarks regressed\n"; want != stderr.String() {
+		t.Errorf("want stderr %q, got %q", want, stderr)
+	}
+}
+
+func TestBenchFailsIfSolverFails(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{2: "x\n"})
+	a, _, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"bench", "-inputs", store.Dir, "-day", "2", "-n", "1"}); code != 1 {
+		t.Fatalf("want exit code 1, got %d", code)
+	}
+	if want := "aoc bench: day 2, part 1: bad input\n"; want != stderr.String() {
+		t.Errorf("want stderr %q, got %q", want, stderr)
+	}
+}
+
+func TestPrintBenchResults(t *testing.T) {
+	t.Parallel()
+	results := []solver.BenchResult{
+		{Day: 1, Part: 1, Iterations: 10, NsPerOp: 12345, AllocsPerOp: 7, BytesPerOp: 2048},
+		{Day: 11, Part: 2, Iterations: 10, NsPerOp: 987654321, AllocsPerOp: 120, BytesPerOp: 64},
+	}
+	var buf strings.Builder
+	if err := printBenchResults(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	want := "  DAY  PART      NS/OP  ALLOCS/OP  BYTES/OP\n" +
+		"    1     1      12345          7      2048\n" +
+		"   11     2  987654321        120        64\n"
+	if got := buf.String(); want != got {
+		t.Error(cmp.Diff(want, got))
+	}
+}
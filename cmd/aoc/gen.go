@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+)
+
+// gen implements the "aoc gen" subcommand. It writes a random puzzle input for
+// a day that is reproducible from its seed.
+func (a *app) gen(args []string) error {
+	flags := a.newFlagSet("gen")
+	day := flags.Int("day", 0, "puzzle day to generate an input for (1-25)")
+	size := flags.Int("size", 1000, "size of the input; its meaning depends on the day (e.g. number of lines, elves or directories)")
+	seed := flags.Int64("seed", 1, "seed for the random number generator; the same seed always generates the same input")
+	out := flags.String("o", "", "write the input to `file` instead of stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *day == 0 {
+		return errors.New("a day must be given with the -day flag")
+	}
+	generate, ok := a.registry.LookupGenerator(*day)
+	if !ok {
+		return fmt.Errorf("no input generator exists for day %d (days with generators: %v)", *day, a.registry.GeneratorDays())
+	}
+	var w io.Writer = a.stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := generate(w, rand.New(rand.NewSource(*seed)), *size); err != nil {
+		return err
+	}
+	if f, ok := w.(*os.File); ok && *out != "" {
+		return f.Close()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenWritesReproducibleInputForSeed(t *testing.T) {
+	t.Parallel()
+	gen := func(args ...string) string {
+		t.Helper()
+		a, stdout, stderr := newTestApp(newTestRegistry(), "")
+		if code := a.main(append([]string{"gen", "-day", "1"}, args...)); code != 0 {
+			t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+		}
+		return stdout.String()
+	}
+	first := gen("-seed", "7", "-size", "20")
+	if len(first) != 21 {
+		t.Errorf("want 20 digits and a newline, got %q", first)
+	}
+	if again := gen("-seed", "7", "-size", "20"); first != again {
+		t.Errorf("want the same input for the same seed, got %q and %q", first, again)
+	}
+	if other := gen("-seed", "8", "-size", "20"); first == other {
+		t.Errorf("want a different input for a different seed, got %q twice", first)
+	}
+	out := filepath.Join(t.TempDir(), "day01.txt")
+	if stdout := gen("-seed", "7", "-size", "20", "-o", out); stdout != "" {
+		t.Errorf("want no output with -o, got %q", stdout)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != string(data) {
+		t.Errorf("want file to hold %q, got %q", first, data)
+	}
+}
+
+func TestGenFailsForDayWithoutGenerator(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"No day": {
+			args:    nil,
+			wantErr: "aoc gen: a day must be given with the -day flag\n",
+		},
+		"Day without a generator": {
+			args:    []string{"-day", "2"},
+			wantErr: "aoc gen: no input generator exists for day 2 (days with generators: [1])\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			a, _, stderr := newTestApp(newTestRegistry(), "")
+			if code := a.main(append([]string{"gen"}, tc.args...)); code != 1 {
+				t.Errorf("want exit code 1, got %d", code)
+			}
+			if got := stderr.String(); !strings.HasSuffix(got, tc.wantErr) {
+				t.Errorf("want stderr %q, got %q", tc.wantErr, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/aculclasure/aoc2022/inputs"
+)
+
+// listInputs implements the "aoc inputs" subcommand.
+func (a *app) listInputs(args []string) error {
+	flags := a.newFlagSet("inputs")
+	storeDir := flags.String("inputs", inputs.DefaultStore().Dir, "root directory of the input store (default $"+inputs.EnvDir+" or "+inputs.DefaultDir+")")
+	record := flags.Bool("record", false, "record the checksum of every input that has no recorded checksum")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	store := inputs.NewStore(*storeDir)
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tNAME\tSHA256\tSTATUS")
+	numMismatched := 0
+	for day := 1; day <= 25; day++ {
+		names, err := store.Names(day)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			status := "unrecorded"
+			in, err := store.Load(day, name)
+			switch {
+			case errors.Is(err, inputs.ErrChecksumMismatch):
+				status = "MISMATCH"
+				numMismatched++
+			case err != nil:
+				return err
+			case in.Verified:
+				status = "ok"
+			case *record:
+				if err := store.Record(day, name); err != nil {
+					return err
+				}
+				status = "recorded"
+			}
+			sum := ""
+			if in != nil {
+				sum = in.SHA256
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", day, name, sum, status)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if numMismatched > 0 {
+		return fmt.Errorf("%d inputs do not match their recorded checksums", numMismatched)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInputsRecordsAndVerifiesChecksums(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n"})
+	writeStoreFile(t, store.Path(1, "example"), "ab\n")
+	inputsTable := func(args ...string) (string, int) {
+		t.Helper()
+		a, stdout, _ := newTestApp(newTestRegistry(), "")
+		code := a.main(append([]string{"inputs", "-inputs", store.Dir}, args...))
+		return stdout.String(), code
+	}
+
+	got, code := inputsTable()
+	if code != 0 {
+		t.Fatalf("want exit code 0, got %d", code)
+	}
+	for _, want := range []string{"DAY  NAME", "unrecorded\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want output to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	if _, code := inputsTable("-record"); code != 0 {
+		t.Fatalf("want exit code 0 with -record, got %d", code)
+	}
+	sums, err := store.Sums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sums) != 2 {
+		t.Errorf("want checksums recorded for 2 inputs, got %v", sums)
+	}
+	got, _ = inputsTable()
+	if n := strings.Count(got, "  ok\n"); n != 2 {
+		t.Errorf("want 2 verified inputs, got:\n%s", got)
+	}
+
+	if err := os.WriteFile(store.Path(1, inputs.Real), []byte("changed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"inputs", "-inputs", store.Dir}); code != 1 {
+		t.Fatalf("want exit code 1 for a modified input, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "MISMATCH") {
+		t.Errorf("want the modified input to be reported as MISMATCH, got:\n%s", stdout)
+	}
+	if want := "aoc inputs: 1 inputs do not match their recorded checksums\n"; !cmp.Equal(want, stderr.String()) {
+		t.Error(cmp.Diff(want, stderr.String()))
+	}
+}
@@ -0,0 +1,130 @@
+// Command aoc runs the Advent of Code 2022 puzzle solutions contained in this
+// module.
+//
+// Usage:
+//
+//	aoc run -day 7 -part 2 -input path/to/input.txt
+//
+// An input of "-" reads the puzzle input from standard input. When no input is
+// given, the named input (see -name) is loaded from the input store, which is
+// rooted at $AOC_INPUT_DIR or at the inputs directory relative to the current
+// directory.
+//
+// A single day and part can be profiled with the -cpuprofile, -memprofile and
+// -trace flags of "aoc run", and -timing reports the time spent parsing the
+// input and solving the puzzle for solvers that measure it:
+//
+//	aoc run -day 11 -part 2 -timing -cpuprofile cpu.out
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  bench    time every solution and compare against a baseline
+  gen      generate a random puzzle input for a day
+  inputs   list the inputs in the input store and their checksums
+  list     list the registered solutions
+  new      generate the skeleton for a new day
+  run      run the solution for a day and part
+  serve    serve every solution over HTTP
+  verify   check every solution against its recorded answers
+  watch    re-run the solution for a day when its source or input changes
+
+Run "aoc <command> -h" for help with a command.
+`
+
+// command represents an aoc subcommand. It accepts the command line arguments
+// following the subcommand name.
+type command func(app *app, args []string) error
+
+var commands = map[string]command{
+	"bench":  (*app).bench,
+	"gen":    (*app).gen,
+	"inputs": (*app).listInputs,
+	"list":   (*app).list,
+	"new":    (*app).newDay,
+	"run":    (*app).run,
+	"serve":  (*app).serve,
+	"verify": (*app).verify,
+	"watch":  (*app).watchDay,
+}
+
+// app holds the standard streams used by the aoc subcommands along with the
+// registry holding the solvers they run.
+type app struct {
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+	registry *solver.Registry
+}
+
+func main() {
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, registry: solver.DefaultRegistry}
+	os.Exit(a.main(os.Args[1:]))
+}
+
+// main dispatches args to the matching subcommand and returns the process exit
+// code.
+func (a *app) main(args []string) int {
+	if len(args) < 1 {
+		fmt.Fprint(a.stderr, usage)
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(a.stderr, "aoc: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	err := cmd(a, args[1:])
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		fmt.Fprintf(a.stderr, "aoc %s: %s\n", args[0], err)
+		return 1
+	}
+}
+
+// errUsage is returned by a subcommand when it was invoked with invalid flags.
+// The flag package has already reported the problem in this case.
+var errUsage = errors.New("invalid usage")
+
+// newFlagSet returns a FlagSet for the named subcommand that reports errors to
+// the app's stderr.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("aoc "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parseFlags parses args into fs, translating parse failures into errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	switch {
+	case err == nil:
+		if fs.NArg() > 0 {
+			fmt.Fprintf(fs.Output(), "unexpected arguments: %v\n", fs.Args())
+			fs.Usage()
+			return errUsage
+		}
+		return nil
+	case errors.Is(err, flag.ErrHelp):
+		return err
+	default:
+		return errUsage
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestRegistry returns a registry holding the following test solvers:
+//
+//	day 1, part 1  the length of the trimmed input
+//	day 1, part 2  the trimmed input in upper case
+//	day 2, part 1  fails with an error
+//	day 3, part 1  the trimmed input repeated on two lines
+//
+// Day 1 also has an input generator that writes size random digits.
+func newTestRegistry() *solver.Registry {
+	r := solver.NewRegistry()
+	r.Register(solver.New(1, 1, func(input io.Reader) (solver.Answer, error) {
+		text, err := readTrimmed(input)
+		return solver.IntAnswer(len(text)), err
+	}))
+	r.Register(solver.New(1, 2, func(input io.Reader) (solver.Answer, error) {
+		text, err := readTrimmed(input)
+		return solver.StringAnswer(strings.ToUpper(text)), err
+	}))
+	r.Register(solver.New(2, 1, func(io.Reader) (solver.Answer, error) {
+		return solver.Answer{}, errors.New("bad input")
+	}))
+	r.Register(solver.New(3, 1, func(input io.Reader) (solver.Answer, error) {
+		text, err := readTrimmed(input)
+		return solver.StringAnswer(text + "\n" + text), err
+	}))
+	r.RegisterGenerator(1, func(w io.Writer, rng *rand.Rand, size int) error {
+		for i := 0; i < size; i++ {
+			if _, err := io.WriteString(w, string(rune('0'+rng.Intn(10)))); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	})
+	return r
+}
+
+func readTrimmed(input io.Reader) (string, error) {
+	data, err := io.ReadAll(input)
+	return strings.TrimSpace(string(data)), err
+}
+
+// newTestApp returns an app running the solvers in reg that reads stdin from
+// the given string and collects its output in the returned buffers.
+func newTestApp(reg *solver.Registry, stdin string) (a *app, stdout, stderr *bytes.Buffer) {
+	stdout, stderr = new(bytes.Buffer), new(bytes.Buffer)
+	a = &app{stdin: strings.NewReader(stdin), stdout: stdout, stderr: stderr, registry: reg}
+	return a, stdout, stderr
+}
+
+// writeStoreFile writes data to path, creating its directory if needed.
+func writeStoreFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestStore returns a store in a temporary directory holding a real input
+// for each of the given days.
+func newTestStore(t *testing.T, days map[int]string) *inputs.Store {
+	t.Helper()
+	store := inputs.NewStore(t.TempDir())
+	for day, data := range days {
+		writeStoreFile(t, store.Path(day, inputs.Real), data)
+	}
+	return store
+}
+
+func TestAppMainReturnsExitCodes(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n", 2: "x\n"})
+	testCases := map[string]struct {
+		args       []string
+		want       int
+		wantStderr string
+	}{
+		"No command exits with 2": {
+			args:       nil,
+			want:       2,
+			wantStderr: "usage: aoc <command>",
+		},
+		"Unknown command exits with 2": {
+			args:       []string{"frobnicate"},
+			want:       2,
+			wantStderr: `unknown command "frobnicate"`,
+		},
+		"Help flag exits with 0": {
+			args: []string{"list", "-h"},
+			want: 0,
+		},
+		"Undefined flag exits with 2": {
+			args:       []string{"list", "-bogus"},
+			want:       2,
+			wantStderr: "flag provided but not defined: -bogus",
+		},
+		"Unexpected argument exits with 2": {
+			args:       []string{"list", "extra"},
+			want:       2,
+			wantStderr: "unexpected arguments: [extra]",
+		},
+		"Successful command exits with 0": {
+			args: []string{"run", "-day", "1", "-inputs", store.Dir},
+			want: 0,
+		},
+		"Failing command exits with 1": {
+			args:       []string{"run", "-day", "2", "-inputs", store.Dir},
+			want:       1,
+			wantStderr: "aoc run: 1 of 1 solutions failed",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			a, _, stderr := newTestApp(newTestRegistry(), "")
+			got := a.main(tc.args)
+			if tc.want != got {
+				t.Errorf("want exit code %d, got %d (stderr: %s)", tc.want, got, stderr)
+			}
+			if !strings.Contains(stderr.String(), tc.wantStderr) {
+				t.Errorf("want stderr to contain %q, got:\n%s", tc.wantStderr, stderr)
+			}
+		})
+	}
+}
+
+func TestListPrintsRegisteredSolvers(t *testing.T) {
+	t.Parallel()
+	a, stdout, _ := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"list"}); code != 0 {
+		t.Fatalf("want exit code 0, got %d", code)
+	}
+	want := "day 1, part 1\nday 1, part 2\nday 2, part 1\nday 3, part 1\n"
+	if got := stdout.String(); want != got {
+		t.Error(cmp.Diff(want, got))
+	}
+}
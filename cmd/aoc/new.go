@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/scaffold"
+)
+
+// newDay implements the "aoc new" subcommand.
+func (a *app) newDay(args []string) error {
+	flags := a.newFlagSet("new")
+	day := flags.Int("day", 0, "puzzle day to generate (1-25)")
+	pkg := flags.String("pkg", "", "name of the package to generate")
+	root := flags.String("root", ".", "root directory of the module")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if len(a.registry.Day(*day)) > 0 {
+		return fmt.Errorf("solvers are already registered for day %d", *day)
+	}
+	module, err := scaffold.ModulePath(*root)
+	if err != nil {
+		return err
+	}
+	written, err := scaffold.Generate(scaffold.Config{Root: *root, Module: module, Day: *day, Package: *pkg})
+	for _, path := range written {
+		fmt.Fprintf(a.stdout, "created %s\n", path)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "save the puzzle input as %s\n", inputs.DefaultStore().Path(*day, inputs.Real))
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewGeneratesDayWithoutPuzzleInput(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/aoc\n\ngo 1.18\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"new", "-day", "12", "-pkg", "hills", "-root", root}); code != 0 {
+		t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	got := stdout.String()
+	for _, want := range []string{
+		"created " + filepath.Join(root, "hills", "hills.go") + "\n",
+		"created " + filepath.Join(root, "puzzles", "hills.go") + "\n",
+		"save the puzzle input as ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want output to contain %q, got:\n%s", want, got)
+		}
+	}
+	input := filepath.Join(root, "inputs", "2022", "day12.txt")
+	if _, err := os.Stat(input); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("want no puzzle input to be created at %s, got error %v", input, err)
+	}
+}
+
+func TestNewFailsForDayWithSolvers(t *testing.T) {
+	t.Parallel()
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"new", "-day", "1", "-pkg", "calories", "-root", t.TempDir()}); code != 1 {
+		t.Fatalf("want exit code 1, got %d", code)
+	}
+	if want := "aoc new: solvers are already registered for day 1\n"; want != stderr.String() {
+		t.Errorf("want stderr %q, got %q", want, stderr)
+	}
+	if stdout.Len() > 0 {
+		t.Errorf("want no files to be created, got:\n%s", stdout)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+// profileFlags holds the output paths of the profiles requested with the
+// -cpuprofile, -memprofile and -trace flags. An empty path disables a profile.
+type profileFlags struct {
+	cpu   *string
+	mem   *string
+	trace *string
+}
+
+// enabled reports whether any profile was requested.
+func (p profileFlags) enabled() bool {
+	return *p.cpu != "" || *p.mem != "" || *p.trace != ""
+}
+
+// start starts the requested CPU profile and execution trace and returns a
+// function that stops them and writes the heap profile. The returned function
+// must be called once the profiled code has finished running.
+func (p profileFlags) start() (stop func() error, err error) {
+	var stops []func() error
+	stopAll := func() error {
+		var firstErr error
+		for i := len(stops) - 1; i >= 0; i-- {
+			if err := stops[i](); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	if *p.cpu != "" {
+		f, err := os.Create(*p.cpu)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("starting CPU profile: %w", err)
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+	if *p.trace != "" {
+		f, err := os.Create(*p.trace)
+		if err != nil {
+			stopAll()
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			stopAll()
+			return nil, fmt.Errorf("starting execution trace: %w", err)
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+	if *p.mem != "" {
+		path := *p.mem
+		stops = append(stops, func() error {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			// Collect garbage so the profile reflects the live heap at the end
+			// of the run as well as every allocation made during it.
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				f.Close()
+				return fmt.Errorf("writing heap profile: %w", err)
+			}
+			return f.Close()
+		})
+	}
+	return stopAll, nil
+}
+
+// printTiming writes the elapsed time of a result to w along with the time
+// spent in each phase reported by the solver.
+func printTiming(w io.Writer, res solver.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, p := range res.Phases {
+		fmt.Fprintf(tw, "  %s\t%s\n", p.Name, p.Elapsed.Round(time.Microsecond))
+	}
+	fmt.Fprintf(tw, "  total\t%s\n", res.Elapsed.Round(time.Microsecond))
+	return tw.Flush()
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// TestRunWritesRequestedProfiles is not run in parallel because only one CPU
+// profile and execution trace can be active at a time.
+func TestRunWritesRequestedProfiles(t *testing.T) {
+	store := newTestStore(t, map[int]string{1: "abcd\n"})
+	dir := t.TempDir()
+	profiles := map[string]string{
+		"-cpuprofile": filepath.Join(dir, "cpu.out"),
+		"-memprofile": filepath.Join(dir, "mem.out"),
+		"-trace":      filepath.Join(dir, "trace.out"),
+	}
+	args := []string{"run", "-inputs", store.Dir, "-day", "1", "-part", "1"}
+	for flag, path := range profiles {
+		args = append(args, flag, path)
+	}
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main(args); code != 0 {
+		t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	if want := "Day 1, Part 1: 4\n"; want != stdout.String() {
+		t.Errorf("want output %q, got %q", want, stdout)
+	}
+	for flag, path := range profiles {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Errorf("%s: %s", flag, err)
+			continue
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s: want a non-empty profile at %s", flag, path)
+		}
+	}
+}
+
+func TestRunFailsIfProfileCannotBeCreated(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n"})
+	path := filepath.Join(t.TempDir(), "missing", "cpu.out")
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"run", "-inputs", store.Dir, "-day", "1", "-part", "1", "-cpuprofile", path}); code != 1 {
+		t.Fatalf("want exit code 1, got %d", code)
+	}
+	if want := regexp.MustCompile(`^aoc run: open .*cpu\.out: no such file or directory\n$`); !want.MatchString(stderr.String()) {
+		t.Errorf("want stderr matching %s, got %q", want, stderr)
+	}
+	if stdout.Len() > 0 {
+		t.Errorf("want the solver not to run, got output:\n%s", stdout)
+	}
+}
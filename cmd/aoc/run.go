@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+// runOptions holds the flags of the "aoc run" subcommand.
+type runOptions struct {
+	day      int
+	part     int
+	input    string
+	store    *inputs.Store
+	name     string
+	all      bool
+	workers  int
+	format   string
+	timing   bool
+	timeout  time.Duration
+	profiles profileFlags
+}
+
+// parseRunFlags parses the arguments of the "aoc run" subcommand and checks
+// that the flags given can be combined.
+func (a *app) parseRunFlags(args []string) (runOptions, error) {
+	flags := a.newFlagSet("run")
+	day := flags.Int("day", 0, "puzzle day to run (1-25)")
+	part := flags.Int("part", 0, "puzzle part to run (1 or 2); runs both parts when omitted")
+	input := flags.String("input", "", `path to the puzzle input, or "-" for stdin (default is the named input from the input store)`)
+	store, name := storeFlags(flags)
+	all := flags.Bool("all", false, "run every registered solution concurrently and print a summary")
+	workers := flags.Int("workers", runtime.NumCPU(), "maximum number of solutions to run at once with -all")
+	format := flags.String("format", "text", `output format, one of "text", "json" or "jsonl" (JSON Lines)`)
+	timing := flags.Bool("timing", false, "print the elapsed time of each solution and of the phases reported by the solver")
+	timeout := flags.Duration("timeout", 0, "stop solutions that support cancellation after this long (0 means no limit)")
+	profiles := profileFlags{
+		cpu:   flags.String("cpuprofile", "", "write a CPU profile of a single day and part to `file`"),
+		mem:   flags.String("memprofile", "", "write a heap profile of a single day and part to `file`"),
+		trace: flags.String("trace", "", "write an execution trace of a single day and part to `file`"),
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return runOptions{}, err
+	}
+	switch {
+	case *format != "text" && *format != "json" && *format != "jsonl":
+		return runOptions{}, fmt.Errorf(`format must be "text", "json" or "jsonl" (got %q)`, *format)
+	case profiles.enabled() && (*all || *day == 0 || *part == 0):
+		return runOptions{}, errors.New("-cpuprofile, -memprofile and -trace require a single -day and -part")
+	case *all && (*day != 0 || *part != 0 || *input != ""):
+		return runOptions{}, errors.New("-all must not be combined with -day, -part or -input")
+	case !*all && *day == 0:
+		return runOptions{}, errors.New("a day must be given with the -day flag")
+	}
+	return runOptions{
+		day:      *day,
+		part:     *part,
+		input:    *input,
+		store:    inputs.NewStore(*store),
+		name:     *name,
+		all:      *all,
+		workers:  *workers,
+		format:   *format,
+		timing:   *timing,
+		timeout:  *timeout,
+		profiles: profiles,
+	}, nil
+}
+
+// run implements the "aoc run" subcommand.
+func (a *app) run(args []string) error {
+	opts, err := a.parseRunFlags(args)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	var results []solver.Result
+	if opts.all {
+		results = a.runAll(ctx, opts.store, opts.name, opts.workers)
+	} else {
+		solvers, err := a.selectSolvers(opts.day, opts.part)
+		if err != nil {
+			return err
+		}
+		data, path, err := a.readInput(opts.store, opts.day, opts.name, opts.input)
+		if err != nil {
+			return err
+		}
+		stopProfiles, err := opts.profiles.start()
+		if err != nil {
+			return err
+		}
+		for _, s := range solvers {
+			results = append(results, solver.RunContext(ctx, s, path, data))
+		}
+		if err := stopProfiles(); err != nil {
+			return err
+		}
+	}
+
+	if err := printResults(a.stdout, results, opts); err != nil {
+		return err
+	}
+	numFailed := 0
+	for _, res := range results {
+		if res.Err != nil {
+			numFailed++
+		}
+	}
+	if numFailed > 0 {
+		return fmt.Errorf("%d of %d solutions failed", numFailed, len(results))
+	}
+	return nil
+}
+
+// printResults writes the results to w in the format selected by opts.
+func printResults(w io.Writer, results []solver.Result, opts runOptions) error {
+	switch {
+	case opts.format == "json":
+		return printResultsJSON(w, results)
+	case opts.format == "jsonl":
+		return printResultsJSONLines(w, results)
+	case opts.all:
+		return printSummary(w, results)
+	}
+	for _, res := range results {
+		printResult(w, res)
+		if opts.timing {
+			if err := printTiming(w, res); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runAll runs every registered solver against its named input from the store
+// using at most workers goroutines and returns the results ordered by day and
+// part. Solvers whose input cannot be loaded are reported as failed without
+// being run. Solvers still running or waiting to run when ctx is cancelled
+// fail with ctx.Err().
+func (a *app) runAll(ctx context.Context, store *inputs.Store, name string, workers int) []solver.Result {
+	solvers := a.registry.All()
+	results := make([]solver.Result, len(solvers))
+	loaded := make(map[int]*inputs.Input)
+	inputErrs := make(map[int]error)
+	var (
+		jobs    []solver.Job
+		indexes []int
+	)
+	for i, s := range solvers {
+		day := s.Day()
+		if _, ok := loaded[day]; !ok && inputErrs[day] == nil {
+			in, err := store.Load(day, name)
+			if err != nil {
+				inputErrs[day] = err
+			} else {
+				loaded[day] = in
+			}
+		}
+		if err := inputErrs[day]; err != nil {
+			results[i] = solver.Result{Day: day, Part: s.Part(), Err: err}
+			continue
+		}
+		jobs = append(jobs, solver.Job{Solver: s, Name: loaded[day].Path, Input: loaded[day].Data})
+		indexes = append(indexes, i)
+	}
+	for i, res := range solver.RunAllContext(ctx, jobs, workers) {
+		results[indexes[i]] = res
+	}
+	return results
+}
+
+// list implements the "aoc list" subcommand.
+func (a *app) list(args []string) error {
+	flags := a.newFlagSet("list")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	for _, s := range a.registry.All() {
+		fmt.Fprintf(a.stdout, "day %d, part %d\n", s.Day(), s.Part())
+	}
+	return nil
+}
+
+// selectSolvers returns the registered solvers for the given day and part. All
+// of the day's solvers are returned when part is 0 and every registered solver
+// is returned when day is 0.
+func (a *app) selectSolvers(day, part int) ([]solver.Solver, error) {
+	if day == 0 {
+		if part != 0 {
+			return nil, fmt.Errorf("part %d must be given along with a day", part)
+		}
+		return a.registry.All(), nil
+	}
+	if part == 0 {
+		solvers := a.registry.Day(day)
+		if len(solvers) == 0 {
+			return nil, fmt.Errorf("no solution exists for day %d", day)
+		}
+		return solvers, nil
+	}
+	s, ok := a.registry.Lookup(day, part)
+	if !ok {
+		return nil, fmt.Errorf("no solution exists for day %d, part %d", day, part)
+	}
+	return []solver.Solver{s}, nil
+}
+
+// readInput returns the contents of the puzzle input for the given day along
+// with the path it was read from. The input is read from stdin when path is
+// "-" (in which case the returned path is empty), from the file at path when it
+// is non-empty and from the named input in the store otherwise.
+func (a *app) readInput(store *inputs.Store, day int, name, path string) ([]byte, string, error) {
+	switch path {
+	case "-":
+		data, err := io.ReadAll(a.stdin)
+		return data, "", err
+	case "":
+		in, err := store.Load(day, name)
+		if err != nil {
+			return nil, "", err
+		}
+		return in.Data, in.Path, nil
+	}
+	data, err := os.ReadFile(path)
+	return data, path, err
+}
+
+// storeFlags defines the flags selecting the input store and the name of the
+// input to use from it.
+func storeFlags(flags *flag.FlagSet) (dir, name *string) {
+	dir = flags.String("inputs", inputs.DefaultStore().Dir, "root directory of the input store (default $"+inputs.EnvDir+" or "+inputs.DefaultDir+")")
+	name = flags.String("name", inputs.Real, "name of the input to use from the input store (e.g. real, example, stress)")
+	return dir, name
+}
+
+// printResult writes the answer or error for a single result to w. Multi-line
+// answers (like the CRT output of day 10) start on their own line.
+func printResult(w io.Writer, res solver.Result) {
+	if res.Err != nil {
+		fmt.Fprintf(w, "Day %d, Part %d: error: %s\n", res.Day, res.Part, res.Err)
+		return
+	}
+	text := res.Answer.String()
+	if strings.Contains(text, "\n") {
+		fmt.Fprintf(w, "Day %d, Part %d:\n%s\n", res.Day, res.Part, text)
+		return
+	}
+	fmt.Fprintf(w, "Day %d, Part %d: %s\n", res.Day, res.Part, text)
+}
+
+// printSummary writes the results to w as a table. Multi-line answers are
+// written below the table.
+func printSummary(w io.Writer, results []solver.Result) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tELAPSED\tANSWER")
+	var multiline []solver.Result
+	for _, res := range results {
+		answer := res.Answer.String()
+		switch {
+		case res.Err != nil:
+			answer = "error: " + res.Err.Error()
+		case strings.Contains(answer, "\n"):
+			answer = "(see below)"
+			multiline = append(multiline, res)
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\n", res.Day, res.Part, res.Elapsed.Round(time.Microsecond), answer)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, res := range multiline {
+		fmt.Fprintf(w, "\nDay %d, Part %d:\n%s\n", res.Day, res.Part, res.Answer)
+	}
+	return nil
+}
+
+// printResultsJSON writes the results to w as a JSON document with a single
+// results field.
+func printResultsJSON(w io.Writer, results []solver.Result) error {
+	doc := struct {
+		Results []solver.Result `json:"results"`
+	}{Results: results}
+	if doc.Results == nil {
+		doc.Results = []solver.Result{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// printResultsJSONLines writes each result to w as a JSON object on its own
+// line.
+func printResultsJSONLines(w io.Writer, results []solver.Result) error {
+	enc := json.NewEncoder(w)
+	for _, res := range results {
+		if err := enc.Encode(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
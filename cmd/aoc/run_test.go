@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunPrintsAnswers(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n", 3: "xy\n"})
+	testCases := map[string]struct {
+		args  []string
+		stdin string
+		want  string
+	}{
+		"Both parts of a day are run when no part is given": {
+			args: []string{"-day", "1"},
+			want: "Day 1, Part 1: 4\nDay 1, Part 2: ABCD\n",
+		},
+		"Only the given part is run": {
+			args: []string{"-day", "1", "-part", "2"},
+			want: "Day 1, Part 2: ABCD\n",
+		},
+		"Multi-line answers start on their own line": {
+			args: []string{"-day", "3"},
+			want: "Day 3, Part 1:\nxy\nxy\n",
+		},
+		"Input is read from stdin": {
+			args:  []string{"-day", "1", "-part", "1", "-input", "-"},
+			stdin: "abcdefg\n",
+			want:  "Day 1, Part 1: 7\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			a, stdout, stderr := newTestApp(newTestRegistry(), tc.stdin)
+			args := append([]string{"run", "-inputs", store.Dir}, tc.args...)
+			if code := a.main(args); code != 0 {
+				t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+			}
+			if got := stdout.String(); tc.want != got {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestRunTimingPrintsElapsedTime(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n"})
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"run", "-inputs", store.Dir, "-day", "1", "-part", "1", "-timing"}); code != 0 {
+		t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	want := regexp.MustCompile(`^Day 1, Part 1: 4\n  total  \S+\n$`)
+	if got := stdout.String(); !want.MatchString(got) {
+		t.Errorf("want output matching %s, got:\n%s", want, got)
+	}
+}
+
+func TestRunAllPrintsSummaryOfEverySolver(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n", 3: "xy\n"})
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"run", "-inputs", store.Dir, "-all", "-workers", "2"}); code != 1 {
+		t.Fatalf("want exit code 1, got %d", code)
+	}
+	got := stdout.String()
+	for _, want := range []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^DAY +PART +ELAPSED +ANSWER$`),
+		regexp.MustCompile(`(?m)^1 +1 +\S+ +4$`),
+		regexp.MustCompile(`(?m)^1 +2 +\S+ +ABCD$`),
+		regexp.MustCompile(`(?m)^2 +1 +\S+ +error: open .*day02\.txt: no such file or directory$`),
+		regexp.MustCompile(`(?m)^3 +1 +\S+ +\(see below\)$`),
+		regexp.MustCompile(`\n\nDay 3, Part 1:\nxy\nxy\n$`),
+	} {
+		if !want.MatchString(got) {
+			t.Errorf("want output matching %s, got:\n%s", want, got)
+		}
+	}
+	if want := "aoc run: 1 of 4 solutions failed\n"; want != stderr.String() {
+		t.Errorf("want stderr %q, got %q", want, stderr)
+	}
+}
+
+// resultSummary returns the day, part, answer and error of res, leaving out
+// the fields that differ between runs.
+func resultSummary(res solver.Result) string {
+	return fmt.Sprintf("day %d, part %d: %q (%s) error: %v", res.Day, res.Part, res.Answer, res.Answer.Type(), res.Err)
+}
+
+func TestRunFormatJSONWritesResultsDocument(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n", 2: "x\n", 3: "xy\n"})
+	a, stdout, _ := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"run", "-inputs", store.Dir, "-all", "-format", "json"}); code != 1 {
+		t.Fatalf("want exit code 1, got %d", code)
+	}
+	var doc struct {
+		Results []solver.Result `json:"results"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding output %s: %s", stdout, err)
+	}
+	want := []string{
+		`day 1, part 1: "4" (int) error: <nil>`,
+		`day 1, part 2: "ABCD" (string) error: <nil>`,
+		`day 2, part 1: "<nil>" () error: bad input`,
+		`day 3, part 1: "xy\nxy" (string) error: <nil>`,
+	}
+	var got []string
+	for _, res := range doc.Results {
+		got = append(got, resultSummary(res))
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRunFormatJSONLinesWritesResultPerLine(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n"})
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"run", "-inputs", store.Dir, "-day", "1", "-format", "jsonl"}); code != 0 {
+		t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	want := []string{
+		`day 1, part 1: "4" (int) error: <nil>`,
+		`day 1, part 2: "ABCD" (string) error: <nil>`,
+	}
+	var got []string
+	scn := bufio.NewScanner(stdout)
+	for scn.Scan() {
+		var res solver.Result
+		if err := json.Unmarshal(scn.Bytes(), &res); err != nil {
+			t.Fatalf("decoding line %s: %s", scn.Text(), err)
+		}
+		got = append(got, resultSummary(res))
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRunRejectsInvalidFlagCombinations(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		args    []string
+		want    int
+		wantErr string
+	}{
+		"Unknown format": {
+			args:    []string{"-day", "1", "-format", "xml"},
+			want:    1,
+			wantErr: `format must be "text", "json" or "jsonl" (got "xml")`,
+		},
+		"Profile without a part": {
+			args:    []string{"-day", "1", "-cpuprofile", "cpu.out"},
+			want:    1,
+			wantErr: "-cpuprofile, -memprofile and -trace require a single -day and -part",
+		},
+		"Profile with -all": {
+			args:    []string{"-all", "-trace", "trace.out"},
+			want:    1,
+			wantErr: "-cpuprofile, -memprofile and -trace require a single -day and -part",
+		},
+		"All with a day": {
+			args:    []string{"-all", "-day", "1"},
+			want:    1,
+			wantErr: "-all must not be combined with -day, -part or -input",
+		},
+		"All with an input": {
+			args:    []string{"-all", "-input", "-"},
+			want:    1,
+			wantErr: "-all must not be combined with -day, -part or -input",
+		},
+		"No day": {
+			args:    []string{"-part", "1"},
+			want:    1,
+			wantErr: "a day must be given with the -day flag",
+		},
+		"Day without solvers": {
+			args:    []string{"-day", "9"},
+			want:    1,
+			wantErr: "no solution exists for day 9",
+		},
+		"Part without a solver": {
+			args:    []string{"-day", "2", "-part", "2"},
+			want:    1,
+			wantErr: "no solution exists for day 2, part 2",
+		},
+		"Invalid timeout": {
+			args:    []string{"-day", "1", "-timeout", "soon"},
+			want:    2,
+			wantErr: `invalid value "soon" for flag -timeout`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			a, stdout, stderr := newTestApp(newTestRegistry(), "")
+			if code := a.main(append([]string{"run"}, tc.args...)); tc.want != code {
+				t.Errorf("want exit code %d, got %d", tc.want, code)
+			}
+			if !strings.Contains(stderr.String(), tc.wantErr) {
+				t.Errorf("want stderr to contain %q, got:\n%s", tc.wantErr, stderr)
+			}
+			if stdout.Len() > 0 {
+				t.Errorf("want no output, got:\n%s", stdout)
+			}
+		})
+	}
+}
+
+func TestPrintSummaryListsMultiLineAnswersBelowTheTable(t *testing.T) {
+	t.Parallel()
+	results := []solver.Result{
+		{Day: 1, Part: 1, Answer: solver.IntAnswer(70296), Elapsed: 1500 * time.Microsecond},
+		{Day: 2, Part: 1, Err: fmt.Errorf("bad input"), Elapsed: 2 * time.Millisecond},
+		{Day: 10, Part: 2, Answer: solver.StringAnswer("##\n.."), Elapsed: 250 * time.Microsecond},
+	}
+	var buf strings.Builder
+	if err := printSummary(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	want := "DAY  PART  ELAPSED  ANSWER\n" +
+		"1    1     1.5ms    70296\n" +
+		"2    1     2ms      error: bad input\n" +
+		"10   2     250µs    (see below)\n" +
+		"\nDay 10, Part 2:\n##\n..\n"
+	if got := buf.String(); want != got {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/aculclasure/aoc2022/server"
+)
+
+// serve implements the "aoc serve" subcommand. It serves every registered
+// solver over HTTP until interrupted.
+func (a *app) serve(args []string) error {
+	flags := a.newFlagSet("serve")
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	maxInput := flags.Int64("max-input", server.DefaultMaxInputBytes, "largest puzzle input to accept in bytes")
+	timeout := flags.Duration("timeout", server.DefaultTimeout, "how long a solution may run before the request fails")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:           &server.Handler{Registry: a.registry, MaxInputBytes: *maxInput, Timeout: *timeout},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	fmt.Fprintf(a.stdout, "serving solutions on http://%s (press Ctrl+C to stop)\n", ln.Addr())
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package main
+
+// The puzzle packages register their solvers with the solver package when they
+// are initialized.
+import _ "github.com/aculclasure/aoc2022/puzzles"
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"text/tabwriter"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+// verify implements the "aoc verify" subcommand. Days with no stored input are
+// reported as errors unless -skip-missing is given, and parts with no recorded
+// answer fail the verification unless -record is given.
+func (a *app) verify(args []string) error {
+	flags := a.newFlagSet("verify")
+	storeDir, name := storeFlags(flags)
+	record := flags.Bool("record", false, "record the current answer for every part that has no recorded answer")
+	skipMissing := flags.Bool("skip-missing", false, "skip days that have no stored input instead of reporting them as errors")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	results, err := a.verifyDays(inputs.NewStore(*storeDir), *name, *record, *skipMissing)
+	if err != nil {
+		return err
+	}
+	return printVerifications(a.stdout, results, *record)
+}
+
+// verifyDays verifies every registered solver against the named input from the
+// store and returns the verifications ordered by day and part. The current
+// answers of parts without a recorded answer are recorded when record is true.
+// Days without a stored input are left out when skipMissing is true and are
+// reported with StatusError otherwise.
+func (a *app) verifyDays(store *inputs.Store, name string, record, skipMissing bool) ([]solver.Verification, error) {
+	var results []solver.Verification
+	for _, day := range a.registeredDays() {
+		in, err := store.Load(day, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			if skipMissing {
+				continue
+			}
+			for _, s := range a.registry.Day(day) {
+				results = append(results, solver.Verification{Day: day, Part: s.Part(), Status: solver.StatusError, Err: err})
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		want, err := readAnswers(store.AnswersPath(day, name))
+		if err != nil {
+			return nil, err
+		}
+		recorded := false
+		for _, s := range a.registry.Day(day) {
+			v := solver.Verify(s, in.Reader(), want)
+			if v.Status == solver.StatusMissing && record {
+				want[v.Part] = v.Got
+				recorded = true
+			}
+			results = append(results, v)
+		}
+		if recorded {
+			if err := writeAnswers(store.AnswersPath(day, name), want); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// printVerifications writes the verifications to w as a table followed by the
+// details of every part that regressed or, unless its answer was recorded,
+// has no recorded answer. An error is returned if any such part exists.
+func printVerifications(w io.Writer, results []solver.Verification, recorded bool) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tSTATUS")
+	for _, v := range results {
+		fmt.Fprintf(tw, "%d\t%d\t%s\n", v.Day, v.Part, v.Status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	numRegressed, numMissing := 0, 0
+	for _, v := range results {
+		switch {
+		case v.Regressed():
+			numRegressed++
+		case v.Status == solver.StatusMissing && !recorded:
+			numMissing++
+		default:
+			continue
+		}
+		fmt.Fprintf(w, "\nday %d, part %d:\n", v.Day, v.Part)
+		switch {
+		case v.Err != nil:
+			fmt.Fprintf(w, "error: %s\n", v.Err)
+		case v.Status == solver.StatusMissing:
+			fmt.Fprintf(w, "no recorded answer (run with -record to record it), got:\n%s\n", v.Got)
+		default:
+			fmt.Fprintf(w, "answer mismatch (-want +got):\n%s", v.Diff())
+		}
+	}
+	if numRegressed > 0 || numMissing > 0 {
+		return fmt.Errorf("%d of %d answers regressed, %d missing", numRegressed, len(results), numMissing)
+	}
+	return nil
+}
+
+// registeredDays returns every day with at least one registered solver in
+// ascending order.
+func (a *app) registeredDays() []int {
+	var days []int
+	for _, s := range a.registry.All() {
+		if len(days) == 0 || days[len(days)-1] != s.Day() {
+			days = append(days, s.Day())
+		}
+	}
+	return days
+}
+
+// readAnswers reads the recorded answers from path. An empty set of answers is
+// returned if the file does not exist.
+func readAnswers(path string) (solver.Answers, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return solver.Answers{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	answers, err := solver.ReadAnswers(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// writeAnswers records the answers in the file at path.
+func writeAnswers(path string, answers solver.Answers) error {
+	var buf bytes.Buffer
+	if _, err := answers.WriteTo(&buf); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordAnswers writes the answers for the real input of day to store.
+func recordAnswers(t *testing.T, store *inputs.Store, day int, answers solver.Answers) {
+	t.Helper()
+	if err := writeAnswers(store.AnswersPath(day, inputs.Real), answers); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyReportsStatusOfEveryPart(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n", 2: "x\n"})
+	recordAnswers(t, store, 1, solver.Answers{1: "4", 2: "ABCE"})
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"verify", "-inputs", store.Dir}); code != 1 {
+		t.Fatalf("want exit code 1, got %d", code)
+	}
+	wantTable := "DAY  PART  STATUS\n" +
+		"1    1     PASS\n" +
+		"1    2     FAIL\n" +
+		"2    1     ERROR\n" +
+		"3    1     ERROR\n"
+	got := stdout.String()
+	if !strings.HasPrefix(got, wantTable) {
+		t.Errorf("want output to start with the table:\n%s\ngot:\n%s", wantTable, got)
+	}
+	for _, want := range []string{
+		"day 1, part 2:\nanswer mismatch (-want +got):",
+		"day 2, part 1:\nerror: bad input\n",
+		"day 3, part 1:\nerror: open ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want output to contain %q, got:\n%s", want, got)
+		}
+	}
+	wantErr := "aoc verify: 3 of 4 answers regressed, 0 missing\n"
+	if wantErr != stderr.String() {
+		t.Errorf("want stderr %q, got %q", wantErr, stderr)
+	}
+}
+
+func TestVerifySkipMissingLeavesOutDaysWithoutInput(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n"})
+	recordAnswers(t, store, 1, solver.Answers{1: "4", 2: "ABCD"})
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main([]string{"verify", "-inputs", store.Dir, "-skip-missing"}); code != 0 {
+		t.Fatalf("want exit code 0, got %d (stderr: %s)", code, stderr)
+	}
+	want := "DAY  PART  STATUS\n" +
+		"1    1     PASS\n" +
+		"1    2     PASS\n"
+	if got := stdout.String(); want != got {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestVerifyFailsOnUnrecordedPartsUntilTheyAreRecorded(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[int]string{1: "abcd\n", 3: "xy\n"})
+	recordAnswers(t, store, 1, solver.Answers{1: "4"})
+	args := []string{"verify", "-inputs", store.Dir, "-skip-missing"}
+
+	a, stdout, stderr := newTestApp(newTestRegistry(), "")
+	if code := a.main(args); code != 1 {
+		t.Fatalf("want exit code 1 for unrecorded parts, got %d", code)
+	}
+	wantTable := "DAY  PART  STATUS\n" +
+		"1    1     PASS\n" +
+		"1    2     MISSING\n" +
+		"3    1     MISSING\n"
+	if got := stdout.String(); !strings.HasPrefix(got, wantTable) {
+		t.Errorf("want output to start with the table:\n%s\ngot:\n%s", wantTable, got)
+	}
+	if want := "no recorded answer (run with -record to record it), got:\nxy\nxy\n"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("want output to contain %q, got:\n%s", want, stdout)
+	}
+	if want := "aoc verify: 0 of 3 answers regressed, 2 missing\n"; want != stderr.String() {
+		t.Errorf("want stderr %q, got %q", want, stderr)
+	}
+
+	a, stdout, stderr = newTestApp(newTestRegistry(), "")
+	if code := a.main(append(args, "-record")); code != 0 {
+		t.Fatalf("want exit code 0 with -record, got %d (stderr: %s)", code, stderr)
+	}
+	if want := wantTable; want != stdout.String() {
+		t.Error(cmp.Diff(want, stdout.String()))
+	}
+	for day, want := range map[int]solver.Answers{1: {1: "4", 2: "ABCD"}, 3: {1: "xy\nxy"}} {
+		got, err := readAnswers(store.AnswersPath(day, inputs.Real))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(want, got) {
+			t.Errorf("day %d: %s", day, cmp.Diff(want, got))
+		}
+	}
+
+	a, stdout, stderr = newTestApp(newTestRegistry(), "")
+	if code := a.main(args); code != 0 {
+		t.Fatalf("want exit code 0 once the answers are recorded, got %d (stderr: %s)", code, stderr)
+	}
+	want := "DAY  PART  STATUS\n" +
+		"1    1     PASS\n" +
+		"1    2     PASS\n" +
+		"3    1     PASS\n"
+	if got := stdout.String(); want != got {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestPrintVerificationsShowsDiffOfMismatchedAnswers(t *testing.T) {
+	t.Parallel()
+	results := []solver.Verification{
+		{Day: 10, Part: 2, Status: solver.StatusFail, Want: "##\n..", Got: "##\n.#"},
+		{Day: 11, Part: 1, Status: solver.StatusError, Err: errors.New("boom")},
+	}
+	var buf strings.Builder
+	err := printVerifications(&buf, results, false)
+	if err == nil {
+		t.Fatal("expected an error but did not get one")
+	}
+	if want := "2 of 2 answers regressed, 0 missing"; want != err.Error() {
+		t.Errorf("want error %q, got %q", want, err)
+	}
+	got := buf.String()
+	for _, want := range []string{"10   2     FAIL\n", "answer mismatch (-want +got):\n", `"..",`, `".#",`, "day 11, part 1:\nerror: boom\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("want output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/aculclasure/aoc2022/watch"
+)
+
+// watchDay implements the "aoc watch" subcommand. It re-runs the solution for
+// a day whenever the day's package source or its puzzle input changes. Each
+// run rebuilds the aoc command with "go run" so that source changes take
+// effect.
+func (a *app) watchDay(args []string) error {
+	flags := a.newFlagSet("watch")
+	day := flags.Int("day", 0, "puzzle day to watch (1-25)")
+	part := flags.Int("part", 0, "puzzle part to run (1 or 2); runs both parts when omitted")
+	input := flags.String("input", "", "path to the puzzle input (default is the named input from the input store)")
+	store, name := storeFlags(flags)
+	root := flags.String("root", ".", "root directory of the module to rebuild on every run")
+	interval := flags.Duration("interval", 500*time.Millisecond, "time between checks for modified files")
+	quiet := flags.Duration("debounce", 200*time.Millisecond, "how long files must go unmodified before the solution is re-run")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	switch {
+	case *interval <= 0:
+		fmt.Fprintf(flags.Output(), "-interval must be positive, got %s\n", *interval)
+		flags.Usage()
+		return errUsage
+	case *quiet < 0:
+		fmt.Fprintf(flags.Output(), "-debounce must not be negative, got %s\n", *quiet)
+		flags.Usage()
+		return errUsage
+	}
+	if *day == 0 {
+		return errors.New("a day must be given with the -day flag")
+	}
+	if *input == "-" {
+		return errors.New("watch cannot read the puzzle input from stdin")
+	}
+	solvers, err := a.selectSolvers(*day, *part)
+	if err != nil {
+		return err
+	}
+	dirs, err := sourceDirs(solvers)
+	if err != nil {
+		return err
+	}
+	runArgs := []string{"run", "./cmd/aoc", "run", "-format", "jsonl", "-day", strconv.Itoa(*day)}
+	if *part != 0 {
+		runArgs = append(runArgs, "-part", strconv.Itoa(*part))
+	}
+	inputPath := *input
+	if inputPath == "" {
+		s := inputs.NewStore(*store)
+		inputPath = s.Path(*day, *name)
+		dir, err := filepath.Abs(s.Dir)
+		if err != nil {
+			return err
+		}
+		runArgs = append(runArgs, "-inputs", dir, "-name", *name)
+	} else {
+		inputPath, err = filepath.Abs(inputPath)
+		if err != nil {
+			return err
+		}
+		runArgs = append(runArgs, "-input", inputPath)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	rerun := func(changed []string) {
+		if len(changed) > 0 {
+			fmt.Fprintf(a.stdout, "\n[%s] changed: %s\n", time.Now().Format("15:04:05"), strings.Join(changed, ", "))
+		}
+		a.runGo(ctx, *root, runArgs)
+	}
+	fmt.Fprintf(a.stdout, "watching %s and %s (press Ctrl+C to stop)\n", strings.Join(dirs, ", "), inputPath)
+	rerun(nil)
+	p := &watch.Poller{
+		Paths:    func() ([]string, error) { return watchedFiles(dirs, inputPath) },
+		Interval: *interval,
+		Quiet:    *quiet,
+	}
+	if err := p.Watch(ctx, rerun); !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// runGo runs the go command with args in dir, decodes the JSON Lines results
+// it writes and prints them as a summary table. Build errors written by the
+// go command are passed through to the app's stderr.
+func (a *app) runGo(ctx context.Context, dir string, args []string) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.Stderr = a.stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		fmt.Fprintf(a.stdout, "error: %s\n", err)
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintf(a.stdout, "error: %s\n", err)
+		return
+	}
+	var results []solver.Result
+	scn := bufio.NewScanner(stdout)
+	for scn.Scan() {
+		var res solver.Result
+		if err := json.Unmarshal(scn.Bytes(), &res); err != nil {
+			fmt.Fprintln(a.stdout, scn.Text())
+			continue
+		}
+		results = append(results, res)
+	}
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+	if len(results) > 0 {
+		if err := printSummary(a.stdout, results); err != nil {
+			fmt.Fprintf(a.stderr, "error: printing results: %s\n", err)
+		}
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(a.stdout, "error: go %s: %s\n", strings.Join(args, " "), err)
+	}
+}
+
+// sourceDirs returns the sorted directories holding the source files of the
+// given solvers. An error is returned if a directory does not exist, which is
+// the case when aoc was built with -trimpath or on another machine.
+func sourceDirs(solvers []solver.Solver) ([]string, error) {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, s := range solvers {
+		file, ok := solver.SourceFile(s)
+		if !ok {
+			return nil, fmt.Errorf("cannot find the source of the solver for day %d, part %d", s.Day(), s.Part())
+		}
+		dir := filepath.Dir(file)
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("source directory %s of the solver for day %d, part %d does not exist (was aoc built with -trimpath?)", dir, s.Day(), s.Part())
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// watchedFiles returns the Go source files in dirs, excluding tests, along
+// with the input file.
+func watchedFiles(dirs []string, input string) ([]string, error) {
+	var files []string
+	for _, dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if !strings.HasSuffix(m, "_test.go") {
+				files = append(files, m)
+			}
+		}
+	}
+	return append(files, input), nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func TestWatchRejectsInvalidFlags(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		args    []string
+		want    int
+		wantErr string
+	}{
+		"Non-positive interval": {
+			args:    []string{"-day", "1", "-interval", "0s"},
+			want:    2,
+			wantErr: "-interval must be positive, got 0s",
+		},
+		"Negative debounce": {
+			args:    []string{"-day", "1", "-debounce", "-1s"},
+			want:    2,
+			wantErr: "-debounce must not be negative, got -1s",
+		},
+		"No day": {
+			args:    nil,
+			want:    1,
+			wantErr: "aoc watch: a day must be given with the -day flag",
+		},
+		"Input from stdin": {
+			args:    []string{"-day", "1", "-input", "-"},
+			want:    1,
+			wantErr: "aoc watch: watch cannot read the puzzle input from stdin",
+		},
+		"Day without solvers": {
+			args:    []string{"-day", "9"},
+			want:    1,
+			wantErr: "aoc watch: no solution exists for day 9",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			a, _, stderr := newTestApp(newTestRegistry(), "")
+			if code := a.main(append([]string{"watch"}, tc.args...)); tc.want != code {
+				t.Errorf("want exit code %d, got %d", tc.want, code)
+			}
+			if !strings.Contains(stderr.String(), tc.wantErr) {
+				t.Errorf("want stderr to contain %q, got:\n%s", tc.wantErr, stderr)
+			}
+		})
+	}
+}
+
+// plainSolver is a Solver whose source file cannot be determined.
+type plainSolver struct{}
+
+func (plainSolver) Day() int                               { return 1 }
+func (plainSolver) Part() int                              { return 1 }
+func (plainSolver) Solve(io.Reader) (solver.Answer, error) { return solver.Answer{}, nil }
+
+func TestSourceDirs(t *testing.T) {
+	t.Parallel()
+	dirs, err := sourceDirs(newTestRegistry().All())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 1 || !strings.HasSuffix(dirs[0], "aoc") {
+		t.Errorf("want the directory of the test solvers, got %v", dirs)
+	}
+	_, err = sourceDirs([]solver.Solver{plainSolver{}})
+	if err == nil {
+		t.Error("expected an error but did not get one")
+	}
+}
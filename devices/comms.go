@@ -5,6 +5,8 @@ package devices
 
 import (
 	"errors"
+
+	"github.com/aculclasure/aoc2022/ds"
 )
 
 // HasUniqueChars accepts a slice of runes representing a data stream in the
@@ -20,11 +22,7 @@ func HasUniqueChars(input []rune) (bool, error) {
 		return true, nil
 	}
 
-	set := map[rune]struct{}{}
-	for _, r := range input {
-		set[r] = struct{}{}
-	}
-	return len(set) == len(input), nil
+	return ds.NewSet(input...).Len() == len(input), nil
 }
 
 // StartPacketMarker accepts a string representing a data stream in the elf's
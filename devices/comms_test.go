@@ -1,9 +1,11 @@
 package devices_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/aculclasure/aoc2022/devices"
+	"github.com/aculclasure/aoc2022/solver"
 )
 
 func TestHasUniqueCharsWithEmptyInputReturnsError(t *testing.T) {
@@ -132,3 +134,51 @@ func TestStartMessageMarker(t *testing.T) {
 		})
 	}
 }
+
+func TestDay6SolversReadMultiMegabyteStream(t *testing.T) {
+	t.Parallel()
+	prefix := strings.Repeat("ab", 2<<20)
+	input := []byte(prefix + "cdefghijklmnop\r\n")
+	testCases := map[string]struct {
+		part int
+		want solver.Answer
+	}{
+		"Start-of-packet marker is found after a multi-megabyte prefix": {
+			part: 1,
+			want: solver.IntAnswer(len(prefix) + 2),
+		},
+		"Start-of-message marker is found after a multi-megabyte prefix": {
+			part: 2,
+			want: solver.IntAnswer(len(prefix) + 12),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s, ok := solver.Lookup(6, tc.part)
+			if !ok {
+				t.Fatalf("want a solver registered for day 6, part %d", tc.part)
+			}
+			res := solver.Run(s, input)
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			if tc.want != res.Answer {
+				t.Errorf("want %v, got %v", tc.want, res.Answer)
+			}
+		})
+	}
+}
+
+func TestDay6SolversReturnErrorIfStreamHasNoMarker(t *testing.T) {
+	t.Parallel()
+	for part := 1; part <= 2; part++ {
+		s, ok := solver.Lookup(6, part)
+		if !ok {
+			t.Fatalf("want a solver registered for day 6, part %d", part)
+		}
+		res := solver.Run(s, []byte("abababababababababab\n"))
+		if res.Err == nil {
+			t.Errorf("part %d: expected an error but did not get one (got answer %v)", part, res.Answer)
+		}
+	}
+}
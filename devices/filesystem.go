@@ -1,17 +1,15 @@
 package devices
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 // fileInfoRgx defines what a file information line looks like.
@@ -100,28 +98,27 @@ func (d *Directory) BestDirectoryToCleanup(minSystemFreeSpace int) *Directory {
 	currentUsedSpace := d.TotalSize()
 	allDirs := []*Directory{d}
 	allDirs = append(allDirs, d.AllDescendants()...)
-	var potential []*Directory
+	potential := ds.NewPriorityQueue(func(a, b *Directory) bool {
+		return a.TotalSize() < b.TotalSize()
+	})
 	for _, dir := range allDirs {
 		freedSpace := (totalAvailableSystemSpace - currentUsedSpace) + dir.TotalSize()
 		if freedSpace >= minSystemFreeSpace {
-			potential = append(potential, dir)
+			potential.Push(dir)
 		}
 	}
-	sort.Slice(potential, func(i, j int) bool {
-		return potential[i].TotalSize() < potential[j].TotalSize()
-	})
-	if len(potential) == 0 {
-		return nil
-	}
-	return potential[0]
+	best, _ := potential.Peek()
+	return best
 }
 
 // TreeFromTerminalOutput accepts an io.Reader that points to output captured
 // from the terminal on the elf's device, builds a directory tree from the
 // terminal data and returns the root directory of the tree. An error is returned
 // if the terminal argument is nil or if a problem occurs when analyzing the
-// terminal output.
-func TreeFromTerminalOutput(terminal io.Reader) (*Directory, error) {
+// terminal output. A line that is not a known command or a directory or file
+// listing is reported as a *textio.ParseError. Moving up from the root
+// directory is recorded as a warning.
+func TreeFromTerminalOutput(terminal io.Reader, opts ...textio.Option) (*Directory, error) {
 	if terminal == nil {
 		return nil, errors.New("terminal must be non-nil")
 	}
@@ -132,7 +129,7 @@ func TreeFromTerminalOutput(terminal io.Reader) (*Directory, error) {
 	)
 	rootDir := &Directory{Name: "/"}
 	stk.Push(rootDir)
-	scn := bufio.NewScanner(terminal)
+	scn := textio.NewScanner(terminal, opts...)
 	for scn.Scan() {
 		line = scn.Text()
 		switch {
@@ -142,6 +139,7 @@ func TreeFromTerminalOutput(terminal io.Reader) (*Directory, error) {
 			}
 		case line == "$ cd ..":
 			if stk.Size() <= 1 {
+				scn.Warn(errors.New("cannot move up from the root directory"))
 				continue
 			}
 			stk.Pop()
@@ -152,7 +150,9 @@ func TreeFromTerminalOutput(terminal io.Reader) (*Directory, error) {
 			}
 			dir, err := DirFromLine(line)
 			if err != nil {
-				log.Print(err)
+				if err := scn.Skip(err); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			if cwd.Children == nil {
@@ -176,7 +176,9 @@ func TreeFromTerminalOutput(terminal io.Reader) (*Directory, error) {
 			}
 			f, err := FileFromLine(line)
 			if err != nil {
-				log.Print(err)
+				if err := scn.Skip(err); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			cwd.Files = append(cwd.Files, f)
@@ -187,7 +189,9 @@ func TreeFromTerminalOutput(terminal io.Reader) (*Directory, error) {
 			}
 			dir, err := DirFromLine(line)
 			if err != nil {
-				log.Print(err)
+				if err := scn.Skip(err); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			if cwd.Children == nil {
@@ -200,6 +204,13 @@ func TreeFromTerminalOutput(terminal io.Reader) (*Directory, error) {
 				dir.Parent = cwd
 				cwd.Children[dir.Name] = dir
 			}
+		case line == "$ ls" || strings.TrimSpace(line) == "":
+			// Listing commands and blank lines carry no information.
+		default:
+			err := scn.Skip(fmt.Errorf("unrecognized terminal output line %q", line))
+			if err != nil {
+				return nil, err
+			}
 		}
 
 	}
@@ -268,16 +279,17 @@ func DirFromLine(line string) (*Directory, error) {
 // to be in the form "<filesize> <filename>" where filesize is an integer representing
 // the size of the file and filename is a string. It parses this data and returns
 // a File struct. An error is returned if the line cannot be parsed into a File
-// struct.
+// struct; an invalid file size is reported as a *textio.ParseError giving its
+// column.
 func FileFromLine(line string) (File, error) {
-	fields := strings.Fields(line)
+	fields := textio.Fields(line)
 	if len(fields) < 2 {
 		return File{}, fmt.Errorf(`line must be in the form <filesize> <filename> (got line %s)`, line)
 	}
-	sz, err := strconv.Atoi(fields[0])
+	sz, err := strconv.Atoi(fields[0].Text)
 	if err != nil {
-		return File{}, err
+		return File{}, textio.FieldError(fields[0].Column, fields[0].Text, err)
 	}
 
-	return File{Name: fields[1], Size: sz}, nil
+	return File{Name: fields[1].Text, Size: sz}, nil
 }
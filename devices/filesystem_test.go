@@ -1,11 +1,13 @@
 package devices_test
 
 import (
+	"errors"
 	"sort"
 	"strings"
 	"testing"
 
 	"github.com/aculclasure/aoc2022/devices"
+	"github.com/aculclasure/aoc2022/textio"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -362,3 +364,60 @@ func buildTreeFromExample() *devices.Directory {
 	rootDir.AddSubdir(d)
 	return rootDir
 }
+
+func TestTreeFromTerminalOutputWithMalformedLines(t *testing.T) {
+	t.Parallel()
+	const input = `$ cd /
+$ cd ..
+$ ls
+dir a
+99999999999999999999 b.txt
+14848514 c.dat
+$ frobnicate
+`
+	_, err := devices.TreeFromTerminalOutput(strings.NewReader(input))
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError in strict mode, got %v", err)
+	}
+	if pe.Line != 5 || pe.Column != 1 || pe.Text != "99999999999999999999" {
+		t.Errorf("want line 5, column 1, text 99999999999999999999, got line %d, column %d, text %s", pe.Line, pe.Column, pe.Text)
+	}
+
+	var diags textio.Diagnostics
+	root, err := devices.TreeFromTerminalOutput(strings.NewReader(input), textio.Lenient(), textio.WithDiagnostics(&diags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 14848514, root.TotalSize(); want != got {
+		t.Errorf("want total size %d, got %d", want, got)
+	}
+	var got []string
+	for _, d := range diags.List {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"warning: 2:1: cannot move up from the root directory",
+		`skipped: 5:1: strconv.Atoi: parsing "99999999999999999999": value out of range`,
+		`skipped: 7:1: unrecognized terminal output line "$ frobnicate"`,
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestTreeFromTerminalOutputWithCRLFLineEndingsAndComments(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader("# captured on the device\r\n$ cd /\r\n$ ls\r\ndir a\r\n$ cd a \r\n$ ls\r\n29116 f\r\n$ cd ..\r\n$ cd /\r\n")
+	root, err := devices.TreeFromTerminalOutput(input, textio.TrimTrailingSpace(), textio.SkipComments())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, ok := root.Children["a"]
+	if !ok {
+		t.Fatal("want directory a under the root directory")
+	}
+	if want, got := 29116, a.TotalSize(); want != got {
+		t.Errorf("want %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,161 @@
+package devices
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// GenerateDatastream writes a random datastream of length characters to w.
+// The start-of-message marker only appears in the last 14 characters so that
+// finding it requires scanning the entire stream. An error is returned if
+// length is smaller than 14 or if there is a problem writing to w.
+func GenerateDatastream(w io.Writer, rng *rand.Rand, length int) error {
+	const messageMarkerLen = 14
+	if length < messageMarkerLen {
+		return fmt.Errorf("datastream length must be at least %d (got %d)", messageMarkerLen, length)
+	}
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	// The stream ends with a marker of distinct letters. The rest of the
+	// stream is drawn from fewer letters than the marker length and ends with
+	// the first letter of the marker, so no earlier window (including those
+	// overlapping the marker) can be a marker.
+	bodyLetters := letters[:messageMarkerLen-1]
+	first := rng.Intn(len(bodyLetters))
+	marker := []byte{letters[first]}
+	for _, i := range rng.Perm(len(letters) - 1)[:messageMarkerLen-1] {
+		if i >= first {
+			i++
+		}
+		marker = append(marker, letters[i])
+	}
+	bw := bufio.NewWriter(w)
+	for i := 0; i < length-messageMarkerLen-1; i++ {
+		bw.WriteByte(bodyLetters[rng.Intn(len(bodyLetters))])
+	}
+	if length > messageMarkerLen {
+		bw.WriteByte(marker[0])
+	}
+	bw.Write(marker)
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+// genDir is a directory in a generated file system.
+type genDir struct {
+	name     string
+	children []*genDir
+	files    []File
+}
+
+// GenerateTerminalOutput writes a random terminal session that explores a
+// file system with numDirs directories (including the root directory) to w.
+// The total size of the files never exceeds the 70000000 bytes available on
+// the device but is large enough that a directory must be deleted to free up
+// space for the update. An error is returned if numDirs is not between 1 and
+// 200000 or if there is a problem writing to w.
+func GenerateTerminalOutput(w io.Writer, rng *rand.Rand, numDirs int) error {
+	const (
+		maxDirs        = 200000
+		maxFilesPerDir = 5
+		maxTotalSize   = 70000000
+	)
+	// Bounding the number of directories keeps the largest file size well
+	// above the smallest one, so rounding the sizes below cannot push the
+	// used space under the limit.
+	if numDirs < 1 || numDirs > maxDirs {
+		return fmt.Errorf("number of directories must be between 1 and %d (got %d)", maxDirs, numDirs)
+	}
+	dirs := []*genDir{{name: "/"}}
+	for i := 1; i < numDirs; i++ {
+		parent := dirs[rng.Intn(len(dirs))]
+		d := &genDir{name: fmt.Sprintf("d%d", i)}
+		parent.children = append(parent.children, d)
+		dirs = append(dirs, d)
+	}
+	numFiles := 0
+	for _, d := range dirs {
+		n := rng.Intn(maxFilesPerDir + 1)
+		d.files = make([]File, n)
+		numFiles += n
+	}
+	// Every directory may have drawn no files, which would leave nothing
+	// to delete.
+	if numFiles == 0 {
+		d := dirs[rng.Intn(len(dirs))]
+		d.files = make([]File, 1)
+		numFiles = 1
+	}
+	// Sizes between 60% and 100% of an even share of the device keep the
+	// used space above the 40000000 bytes that leave 30000000 bytes free,
+	// so the update always requires deleting a directory.
+	maxFileSize := maxTotalSize / numFiles
+	minFileSize := maxFileSize * 6 / 10
+	for _, d := range dirs {
+		for i := range d.files {
+			d.files[i] = File{Name: fmt.Sprintf("f%d.txt", i), Size: minFileSize + 1 + rng.Intn(maxFileSize-minFileSize)}
+		}
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "$ cd /")
+	stk := []*genDir{dirs[0]}
+	visited := map[*genDir]bool{}
+	for len(stk) > 0 {
+		d := stk[len(stk)-1]
+		if !visited[d] {
+			visited[d] = true
+			fmt.Fprintln(bw, "$ ls")
+			for _, c := range d.children {
+				fmt.Fprintf(bw, "dir %s\n", c.name)
+			}
+			for _, f := range d.files {
+				fmt.Fprintf(bw, "%d %s\n", f.Size, f.Name)
+			}
+		}
+		next := -1
+		for i, c := range d.children {
+			if !visited[c] {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			stk = stk[:len(stk)-1]
+			if len(stk) > 0 {
+				fmt.Fprintln(bw, "$ cd ..")
+			}
+			continue
+		}
+		fmt.Fprintf(bw, "$ cd %s\n", d.children[next].name)
+		stk = append(stk, d.children[next])
+	}
+	return bw.Flush()
+}
+
+// GenerateProgram writes a random program of numInstructions noop and addx
+// instructions to w. The X register always stays within the 40 columns of the
+// CRT screen. An error is returned if numInstructions is smaller than 1 or if
+// there is a problem writing to w.
+func GenerateProgram(w io.Writer, rng *rand.Rand, numInstructions int) error {
+	if numInstructions < 1 {
+		return fmt.Errorf("number of instructions must be at least 1 (got %d)", numInstructions)
+	}
+	const numCols = 40
+	x := 1
+	bw := bufio.NewWriter(w)
+	for i := 0; i < numInstructions; i++ {
+		if rng.Intn(3) == 0 {
+			fmt.Fprintln(bw, "noop")
+			continue
+		}
+		next := rng.Intn(numCols)
+		if next == x {
+			fmt.Fprintln(bw, "noop")
+			continue
+		}
+		fmt.Fprintf(bw, "addx %d\n", next-x)
+		x = next
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,98 @@
+package devices_test
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/devices"
+)
+
+func TestGenerateDatastreamPlacesMessageMarkerAtEnd(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		length int
+		seed   int64
+	}{
+		"Stream that is only a marker":            {length: 14, seed: 1},
+		"Stream one character longer than marker": {length: 15, seed: 2},
+		"Long stream": {length: 100000, seed: 3},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := devices.GenerateDatastream(&buf, rand.New(rand.NewSource(tc.seed)), tc.length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := devices.StartMessageMarker(strings.TrimSpace(buf.String()))
+			if tc.length != got {
+				t.Errorf("want %d, got %d", tc.length, got)
+			}
+		})
+	}
+}
+
+func TestGenerateTerminalOutputWithSingleDirectoryRequiresDeletingIt(t *testing.T) {
+	t.Parallel()
+	const (
+		minUsedSpace = 40000000
+		maxUsedSpace = 70000000
+	)
+	for seed := int64(1); seed <= 20; seed++ {
+		var buf bytes.Buffer
+		err := devices.GenerateTerminalOutput(&buf, rand.New(rand.NewSource(seed)), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := devices.TreeFromTerminalOutput(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if used := root.TotalSize(); used <= minUsedSpace || used > maxUsedSpace {
+			t.Errorf("seed %d: want used space between %d and %d, got %d", seed, minUsedSpace, maxUsedSpace, used)
+		}
+	}
+}
+
+func TestGenerateTerminalOutputWithInvalidNumberOfDirectoriesReturnsError(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]int{
+		"No directories returns error":       0,
+		"Too many directories returns error": 200001,
+	}
+	for name, numDirs := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := devices.GenerateTerminalOutput(&buf, rand.New(rand.NewSource(1)), numDirs)
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestGenerateTerminalOutputBuildsTreeWithRequestedDirectories(t *testing.T) {
+	t.Parallel()
+	const numDirs = 500
+	var buf bytes.Buffer
+	err := devices.GenerateTerminalOutput(&buf, rand.New(rand.NewSource(1)), numDirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := devices.TreeFromTerminalOutput(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := 1 + len(root.AllDescendants()); got != numDirs {
+		t.Errorf("want %d directories, got %d", numDirs, got)
+	}
+	const (
+		minUsedSpace = 40000000
+		maxUsedSpace = 70000000
+	)
+	if used := root.TotalSize(); used <= minUsedSpace || used > maxUsedSpace {
+		t.Errorf("want used space between %d and %d, got %d", minUsedSpace, maxUsedSpace, used)
+	}
+}
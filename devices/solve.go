@@ -0,0 +1,99 @@
+package devices
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/aculclasure/aoc2022/textio"
+)
+
+func init() {
+	solver.Register(solver.New(6, 1, solveMarker(StartPacketMarker)))
+	solver.Register(solver.New(6, 2, solveMarker(StartMessageMarker)))
+	solver.Register(solver.NewPhased(7, 1, readTree, solveDay7Part1))
+	solver.Register(solver.NewPhased(7, 2, readTree, solveDay7Part2))
+	solver.Register(solver.New(10, 1, solveDay10Part1))
+	solver.Register(solver.New(10, 2, solveDay10Part2))
+	solver.RegisterGenerator(6, GenerateDatastream)
+	solver.RegisterGenerator(7, GenerateTerminalOutput)
+	solver.RegisterGenerator(10, GenerateProgram)
+}
+
+// solveMarker returns a solver.Func that reads a data stream from its input and
+// applies the marker function to it. The solver.Func returns an error if the
+// data stream is empty or contains no marker.
+func solveMarker(marker func(string) int) solver.Func {
+	return func(input io.Reader) (solver.Answer, error) {
+		data, err := io.ReadAll(textio.NewReader(input))
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		stream := strings.TrimSpace(string(data))
+		if stream == "" {
+			return solver.Answer{}, solver.ErrEmptyInput
+		}
+		pos := marker(stream)
+		if pos < 0 {
+			return solver.Answer{}, errors.New("data stream must contain a marker")
+		}
+		return solver.IntAnswer(pos), nil
+	}
+}
+
+// readTree builds the directory tree from the terminal output of day 7. An
+// error is returned if the input is empty.
+func readTree(input io.Reader) (*Directory, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return nil, err
+	}
+	return TreeFromTerminalOutput(input)
+}
+
+func solveDay7Part1(rootDir *Directory) (solver.Answer, error) {
+	const maxTotalSizePerDirectory = 100000
+	sum := 0
+	for _, m := range DirectoriesSmallerThan(rootDir, maxTotalSizePerDirectory) {
+		sum += m.TotalSize()
+	}
+	return solver.IntAnswer(sum), nil
+}
+
+func solveDay7Part2(rootDir *Directory) (solver.Answer, error) {
+	const minSystemFreeSpace = 30000000
+	best := rootDir.BestDirectoryToCleanup(minSystemFreeSpace)
+	if best == nil {
+		return solver.Answer{}, errors.New("no directory can be removed to free up enough space")
+	}
+	return solver.IntAnswer(best.TotalSize()), nil
+}
+
+func solveDay10Part1(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	sigStrengths, err := SignalStrengths(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	sum := 0
+	for _, s := range sigStrengths {
+		sum += s
+	}
+	return solver.IntAnswer(sum), nil
+}
+
+func solveDay10Part2(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	output, err := DrawOnScreen(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.StringAnswer(output), nil
+}
@@ -1,16 +1,20 @@
 package devices
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
 	"strconv"
-	"strings"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 // CrtScreen represents the cathode ray tube on the elf communication device.
-type CrtScreen [][]string
+// Each cell of its grid holds a pixel, which is empty until it is written.
+type CrtScreen struct {
+	ds.Grid[string]
+}
 
 // WritePixel accepts a CPU cycle number and a pixel value as input, determines
 // the appropriate location on the screen from the CPU cycle number and writes
@@ -21,29 +25,19 @@ func (c *CrtScreen) WritePixel(cpuCycle int, pixelVal string) error {
 	if c == nil {
 		return errors.New("receiver must be non-nil")
 	}
-	if len(*c) == 0 {
+	if c.Rows() == 0 || c.Cols() == 0 {
 		return errors.New("receiver must be a non empty crt matrix")
 	}
-	rowLength := len((*c)[0])
-	row, col := (cpuCycle-1)/rowLength, (cpuCycle-1)%rowLength
-	if row > len(*c) {
-		return fmt.Errorf("cannot insert pixel at row %d, col %d into matrix of size %dx%d", row, col, len((*c)), len((*c)[0]))
-	}
-	if col > (len((*c)[0])) {
-		return fmt.Errorf("cannot insert pixel at row %d, col %d into matrix of size %dx%d", row, col, len((*c)), len((*c)[0]))
+	row, col := (cpuCycle-1)/c.Cols(), (cpuCycle-1)%c.Cols()
+	if cpuCycle < 1 || !c.Set(row, col, pixelVal) {
+		return fmt.Errorf("cannot insert pixel at row %d, col %d into matrix of size %dx%d", row, col, c.Rows(), c.Cols())
 	}
-	(*c)[row][col] = pixelVal
 	return nil
 }
 
 // Output returns the output of the CRT screen as a string.
-func (c CrtScreen) Output() string {
-	var rows []string
-	for _, row := range c {
-		nextRow := strings.Join(row, "")
-		rows = append(rows, nextRow)
-	}
-	return strings.Join(rows, "\n")
+func (c *CrtScreen) Output() string {
+	return c.String()
 }
 
 // NewCrtScreen accepts a number of rows and columns and returns a CrtScreen
@@ -53,15 +47,26 @@ func NewCrtScreen(numRows, numCols int) (*CrtScreen, error) {
 	if numRows < 1 || numCols < 1 {
 		return nil, errors.New("must specify a positive value for number of rows and columns")
 	}
-	var screen CrtScreen
-	for i := 0; i < numRows; i++ {
-		var nextRow []string
-		for j := 0; j < numCols; j++ {
-			nextRow = append(nextRow, "")
-		}
-		screen = append(screen, nextRow)
+	grid, err := ds.NewGrid[string](numRows, numCols)
+	if err != nil {
+		return nil, err
 	}
-	return &screen, nil
+	return &CrtScreen{Grid: *grid}, nil
+}
+
+// CycleEvent describes a CPU cycle during which a pixel was drawn on the CRT
+// screen.
+type CycleEvent struct {
+	// Cycle is the number of the CPU cycle, starting at 1.
+	Cycle int
+	// X is the value of the X register, which holds the middle position of
+	// the sprite, during the cycle.
+	X     int
+	Row   int
+	Col   int
+	Pixel string
+	// Screen is the CRT screen as drawn so far. It must not be modified.
+	Screen *CrtScreen
 }
 
 // cpuInstruction represents an instruction that is given to the video CPU on the
@@ -77,15 +82,47 @@ func (c cpuInstruction) isComplete(currentCycleNum int) bool {
 	return currentCycleNum >= c.completesOnCycleNum
 }
 
+// DrawOpt represents a functional option that can be passed in during a call
+// to the DrawOnScreen function.
+type DrawOpt func(*drawConfig)
+
+// drawConfig holds the settings applied by the DrawOpts given to DrawOnScreen.
+type drawConfig struct {
+	parseOpts []textio.Option
+	observe   func(CycleEvent)
+}
+
+// WithParseOptions accepts textio options and returns a DrawOpt that applies
+// them when reading the instructions.
+func WithParseOptions(opts ...textio.Option) DrawOpt {
+	return func(c *drawConfig) {
+		c.parseOpts = append(c.parseOpts, opts...)
+	}
+}
+
+// WithObserver accepts a function and returns a DrawOpt that makes
+// DrawOnScreen call it with a CycleEvent after every CPU cycle.
+func WithObserver(fn func(CycleEvent)) DrawOpt {
+	return func(c *drawConfig) {
+		c.observe = fn
+	}
+}
+
 // DrawOnScreen accepts line-separated instructions to the device's video CPU,
 // draws pixels on a CRT screen according to the instructions and returns the
 // output that is seen on the CRT screen. An error is returned if the instructions
 // argument is nil, if an invalid instruction line is encountered, or if there is
-// a problem reading the instructions.
-func DrawOnScreen(instructions io.Reader) (string, error) {
+// a problem reading the instructions. A line that is not a noop or an addx with
+// an integer argument is reported as a *textio.ParseError. Pixels that fall
+// outside of the screen are recorded as warnings.
+func DrawOnScreen(instructions io.Reader, opts ...DrawOpt) (string, error) {
 	if instructions == nil {
 		return "", errors.New("instructions must be non-nil")
 	}
+	var cfg drawConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	const (
 		numCols = 40
 		numRows = 6
@@ -96,41 +133,58 @@ func DrawOnScreen(instructions io.Reader) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	scn := bufio.NewScanner(instructions)
+	scn := textio.NewScanner(instructions, cfg.parseOpts...)
+	drawPixel := func() {
+		pixelVal := "."
+		currentPosition := (currentCycleNum - 1) % numCols
+		if overlaps(regValue, currentPosition) {
+			pixelVal = "#"
+		}
+		scn.Warn(screen.WritePixel(currentCycleNum, pixelVal))
+		if cfg.observe != nil {
+			cfg.observe(CycleEvent{
+				Cycle:  currentCycleNum,
+				X:      regValue,
+				Row:    (currentCycleNum - 1) / numCols,
+				Col:    currentPosition,
+				Pixel:  pixelVal,
+				Screen: screen,
+			})
+		}
+	}
 	for scn.Scan() {
 		line := scn.Text()
-		fields := strings.Fields(line)
+		fields := textio.Fields(line)
 		switch {
 		case len(fields) < 1:
-			return "", errors.New("instruction line must be non-empty")
-		case fields[0] != "noop" && fields[0] != "addx":
-			return "", fmt.Errorf("instruction line must start with noop or addx (got %s)", line)
-		case fields[0] == "addx" && len(fields) < 2:
-			return "", fmt.Errorf("addx instruction line must have an adjustment value (got %s)", line)
-		case fields[0] == "noop":
+			if err := scn.Skip(errors.New("instruction line must be non-empty")); err != nil {
+				return "", err
+			}
+		case fields[0].Text != "noop" && fields[0].Text != "addx":
+			if err := scn.Skip(textio.FieldError(fields[0].Column, fields[0].Text, fmt.Errorf("instruction line must start with noop or addx (got %s)", line))); err != nil {
+				return "", err
+			}
+		case fields[0].Text == "addx" && len(fields) < 2:
+			if err := scn.Skip(fmt.Errorf("addx instruction line must have an adjustment value (got %s)", line)); err != nil {
+				return "", err
+			}
+		case fields[0].Text == "noop":
 			instr := cpuInstruction{completesOnCycleNum: currentCycleNum + 1}
 			for !instr.isComplete(currentCycleNum) {
-				pixelVal := "."
-				currentPosition := (currentCycleNum - 1) % numCols
-				if overlaps(regValue, currentPosition) {
-					pixelVal = "#"
-				}
-				screen.WritePixel(currentCycleNum, pixelVal)
+				drawPixel()
 				currentCycleNum++
 			}
 		default:
-			delta, err := strconv.Atoi(fields[1])
+			delta, err := strconv.Atoi(fields[1].Text)
 			if err != nil {
-				return "", err
+				if err := scn.Skip(textio.FieldError(fields[1].Column, fields[1].Text, err)); err != nil {
+					return "", err
+				}
+				continue
 			}
 			instr := cpuInstruction{completesOnCycleNum: currentCycleNum + 2}
 			for !instr.isComplete(currentCycleNum) {
-				pixelVal := "."
-				currentPosition := (currentCycleNum - 1) % numCols
-				if overlaps(regValue, currentPosition) {
-					pixelVal = "#"
-				}
-				screen.WritePixel(currentCycleNum, pixelVal)
+				drawPixel()
 				currentCycleNum++
 			}
 			regValue += delta
@@ -147,8 +201,9 @@ func DrawOnScreen(instructions io.Reader) (string, error) {
 // computes the power cycles from this set of instructions and returns them as a
 // slice of ints. An error is returned if the instructions argument is nil, if
 // an invalid instruction line is encountered, or if there is a problem reading
-// the instructions.
-func SignalStrengths(instructions io.Reader) ([]int, error) {
+// the instructions. Invalid instruction lines are handled as described for
+// DrawOnScreen.
+func SignalStrengths(instructions io.Reader, opts ...textio.Option) ([]int, error) {
 	if instructions == nil {
 		return nil, errors.New("instructions must be non-nil")
 	}
@@ -156,18 +211,24 @@ func SignalStrengths(instructions io.Reader) ([]int, error) {
 	currentCycleNum := 1
 	regValue := 1
 	var sigStrengths []int
-	scn := bufio.NewScanner(instructions)
+	scn := textio.NewScanner(instructions, opts...)
 	for scn.Scan() {
 		line := scn.Text()
-		fields := strings.Fields(line)
+		fields := textio.Fields(line)
 		switch {
 		case len(fields) < 1:
-			return nil, errors.New("instruction line must be non-empty")
-		case fields[0] != "noop" && fields[0] != "addx":
-			return nil, fmt.Errorf("instruction line must start with noop or addx (got %s)", line)
-		case fields[0] == "addx" && len(fields) < 2:
-			return nil, fmt.Errorf("addx instruction line must have an adjustment value (got %s)", line)
-		case fields[0] == "noop":
+			if err := scn.Skip(errors.New("instruction line must be non-empty")); err != nil {
+				return nil, err
+			}
+		case fields[0].Text != "noop" && fields[0].Text != "addx":
+			if err := scn.Skip(textio.FieldError(fields[0].Column, fields[0].Text, fmt.Errorf("instruction line must start with noop or addx (got %s)", line))); err != nil {
+				return nil, err
+			}
+		case fields[0].Text == "addx" && len(fields) < 2:
+			if err := scn.Skip(fmt.Errorf("addx instruction line must have an adjustment value (got %s)", line)); err != nil {
+				return nil, err
+			}
+		case fields[0].Text == "noop":
 			instr := cpuInstruction{completesOnCycleNum: currentCycleNum + 1}
 			for !instr.isComplete(currentCycleNum) {
 				if currentCycleNum%interval == 0 {
@@ -177,9 +238,12 @@ func SignalStrengths(instructions io.Reader) ([]int, error) {
 				currentCycleNum++
 			}
 		default:
-			delta, err := strconv.Atoi(fields[1])
+			delta, err := strconv.Atoi(fields[1].Text)
 			if err != nil {
-				return nil, err
+				if err := scn.Skip(textio.FieldError(fields[1].Column, fields[1].Text, err)); err != nil {
+					return nil, err
+				}
+				continue
 			}
 			instr := cpuInstruction{completesOnCycleNum: currentCycleNum + 2}
 			for !instr.isComplete(currentCycleNum) {
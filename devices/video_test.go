@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/aculclasure/aoc2022/devices"
+	"github.com/aculclasure/aoc2022/textio"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -19,43 +20,47 @@ func TestCrtScreen_WritePixel(t *testing.T) {
 	testCases := map[string]struct {
 		inputCpuCycle int
 		inputPixelVal string
-		want          *devices.CrtScreen
+		want          [][]string
 	}{
 		"Writing a pixel at CPU cycle smaller than number of matrix columns writes pixel at expected location": {
 			inputCpuCycle: 1,
 			inputPixelVal: "#",
-			want: &devices.CrtScreen{
-				[]string{"#", "", "", ""},
-				[]string{"", "", "", ""},
+			want: [][]string{
+				{"#", "", "", ""},
+				{"", "", "", ""},
 			},
 		},
 		"Writing a pixel at CPU cycle equal to number of matrix columns writes pixel at expected location": {
 			inputCpuCycle: numCols,
 			inputPixelVal: "#",
-			want: &devices.CrtScreen{
-				[]string{"", "", "", "#"},
-				[]string{"", "", "", ""},
+			want: [][]string{
+				{"", "", "", "#"},
+				{"", "", "", ""},
 			},
 		},
 		"Writing a pixel at CPU cycle greater than number of matrix columns writes pixel at expected location": {
 			inputCpuCycle: 7,
 			inputPixelVal: "#",
-			want: &devices.CrtScreen{
-				[]string{"", "", "", ""},
-				[]string{"", "", "#", ""},
+			want: [][]string{
+				{"", "", "", ""},
+				{"", "", "#", ""},
 			},
 		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			got, err := devices.NewCrtScreen(numRows, numCols)
+			screen, err := devices.NewCrtScreen(numRows, numCols)
 			if err != nil {
 				t.Fatal(err)
 			}
-			err = got.WritePixel(tc.inputCpuCycle, tc.inputPixelVal)
+			err = screen.WritePixel(tc.inputCpuCycle, tc.inputPixelVal)
 			if err != nil {
 				t.Fatal(err)
 			}
+			var got [][]string
+			for row := 0; row < screen.Rows(); row++ {
+				got = append(got, screen.Row(row))
+			}
 			if !cmp.Equal(tc.want, got) {
 				t.Error(cmp.Diff(tc.want, got))
 			}
@@ -65,6 +70,13 @@ func TestCrtScreen_WritePixel(t *testing.T) {
 
 func TestCrtScreen_WritePixelErrorCases(t *testing.T) {
 	t.Parallel()
+	newScreen := func() *devices.CrtScreen {
+		screen, err := devices.NewCrtScreen(2, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return screen
+	}
 	testCases := map[string]struct {
 		inputScreen   *devices.CrtScreen
 		inputCpuCycle int
@@ -73,12 +85,17 @@ func TestCrtScreen_WritePixelErrorCases(t *testing.T) {
 			inputScreen: nil,
 		},
 		"writing to an out of bounds location on the CRT screen returns error": {
-			inputScreen: &devices.CrtScreen{
-				[]string{"", ""},
-				[]string{"", ""},
-			},
+			inputScreen:   newScreen(),
 			inputCpuCycle: 100,
 		},
+		"writing one cycle past the last pixel on the CRT screen returns error": {
+			inputScreen:   newScreen(),
+			inputCpuCycle: 5,
+		},
+		"writing at a CPU cycle smaller than 1 returns error": {
+			inputScreen:   newScreen(),
+			inputCpuCycle: 0,
+		},
 		"writing to an empty CRT screen returns error": {
 			inputScreen: &devices.CrtScreen{},
 		},
@@ -95,9 +112,14 @@ func TestCrtScreen_WritePixelErrorCases(t *testing.T) {
 
 func TestCrtScreen_OutputGivenAValidCrtScreenReturnsExpectedOutput(t *testing.T) {
 	t.Parallel()
-	screen := &devices.CrtScreen{
-		[]string{".", ".", ".", "#"},
-		[]string{"#", "#", ".", "#"},
+	screen, err := devices.NewCrtScreen(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, pixel := range []string{".", ".", ".", "#", "#", "#", ".", "#"} {
+		if err := screen.WritePixel(i+1, pixel); err != nil {
+			t.Fatal(err)
+		}
 	}
 	want := `...#
 ##.#`
@@ -109,7 +131,7 @@ func TestCrtScreen_OutputGivenAValidCrtScreenReturnsExpectedOutput(t *testing.T)
 
 func TestDrawOnScreenWithValidInstructionsReturnsExpectedScreenOutput(t *testing.T) {
 	t.Parallel()
-	f, err := os.Open("testdata/valid-video-cpu-instructions.txt")
+	f, err := os.Open("testdata/day10-example.txt")
 	if err != nil {
 		t.Fatal(err)
 
@@ -132,7 +154,7 @@ func TestDrawOnScreenWithValidInstructionsReturnsExpectedScreenOutput(t *testing
 
 func TestSignalStrengthsWithValidInstructionsReturnsExpectedSignalStrengthSlice(t *testing.T) {
 	t.Parallel()
-	f, err := os.Open("testdata/valid-video-cpu-instructions.txt")
+	f, err := os.Open("testdata/day10-example.txt")
 	if err != nil {
 		t.Fatal(err)
 
@@ -168,3 +190,55 @@ func TestSignalStrengthsErrorCases(t *testing.T) {
 		})
 	}
 }
+
+func TestDrawOnScreenInLenientModeSkipsInvalidInstructions(t *testing.T) {
+	t.Parallel()
+	var diags textio.Diagnostics
+	input := strings.NewReader("noop\njump 3\naddx\naddx 1\n")
+	got, err := devices.DrawOnScreen(input, devices.WithParseOptions(textio.Lenient(), textio.WithDiagnostics(&diags)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "###\n") {
+		t.Errorf("want screen output starting with ###, got %q", got)
+	}
+	var gotLines []int
+	for _, d := range diags.List {
+		gotLines = append(gotLines, d.Err.Line)
+	}
+	wantLines := []int{2, 3}
+	if !cmp.Equal(wantLines, gotLines) {
+		t.Error(cmp.Diff(wantLines, gotLines))
+	}
+}
+
+func TestDrawOnScreenInStrictModeFailsOnInvalidInstruction(t *testing.T) {
+	t.Parallel()
+	_, err := devices.DrawOnScreen(strings.NewReader("noop\njump 3\n"))
+	if err == nil {
+		t.Fatal("expected an error but did not get one")
+	}
+}
+
+func TestDrawOnScreenPassesCycleEventsToObservers(t *testing.T) {
+	t.Parallel()
+	var got []devices.CycleEvent
+	observe := devices.WithObserver(func(e devices.CycleEvent) {
+		e.Screen = nil
+		got = append(got, e)
+	})
+	_, err := devices.DrawOnScreen(strings.NewReader("noop\naddx 3\naddx -5\n"), observe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []devices.CycleEvent{
+		{Cycle: 1, X: 1, Row: 0, Col: 0, Pixel: "#"},
+		{Cycle: 2, X: 1, Row: 0, Col: 1, Pixel: "#"},
+		{Cycle: 3, X: 1, Row: 0, Col: 2, Pixel: "#"},
+		{Cycle: 4, X: 4, Row: 0, Col: 3, Pixel: "#"},
+		{Cycle: 5, X: 4, Row: 0, Col: 4, Pixel: "#"},
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,156 @@
+package ds
+
+// minDequeCap is the smallest capacity of the ring buffer of a Deque that
+// holds items. It must be a power of 2.
+const minDequeCap = 8
+
+// Deque represents a generic double-ended queue backed by a ring buffer.
+// Items can be pushed and popped at both ends in amortized constant time. The
+// ring buffer grows when it is full and shrinks when it is a quarter full, so
+// the memory held by a Deque is proportional to the number of items it holds
+// rather than to the number of items ever pushed. The zero value is an empty
+// Deque ready to use. A Deque is not safe for concurrent use.
+type Deque[T any] struct {
+	buf  []T
+	head int
+	len  int
+}
+
+// PushFront accepts a value T and adds it to the front of the deque.
+func (d *Deque[T]) PushFront(val T) {
+	d.grow()
+	d.head = d.index(-1)
+	d.buf[d.head] = val
+	d.len++
+}
+
+// PushBack accepts a value T and adds it to the back of the deque.
+func (d *Deque[T]) PushBack(val T) {
+	d.grow()
+	d.buf[d.index(d.len)] = val
+	d.len++
+}
+
+// PopFront removes and returns the item at the front of the deque along with a
+// boolean value indicating if the deque held an item. The boolean value is
+// false when popping from an empty deque.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	front := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.len--
+	d.shrink()
+	return front, true
+}
+
+// PopBack removes and returns the item at the back of the deque along with a
+// boolean value indicating if the deque held an item. The boolean value is
+// false when popping from an empty deque.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	i := d.index(d.len - 1)
+	back := d.buf[i]
+	d.buf[i] = zero
+	d.len--
+	d.shrink()
+	return back, true
+}
+
+// PeekFront returns the item at the front of the deque without removing it,
+// along with a boolean value that is false if the deque is empty.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// PeekBack returns the item at the back of the deque without removing it,
+// along with a boolean value that is false if the deque is empty.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.len-1)], true
+}
+
+// Len returns the number of items in the deque.
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+// Cap returns the number of items the deque can hold before its ring buffer
+// has to grow.
+func (d *Deque[T]) Cap() int {
+	return len(d.buf)
+}
+
+// Items returns a slice of all items in the deque, from front to back,
+// without removing them from the deque.
+func (d *Deque[T]) Items() []T {
+	if d.len == 0 {
+		return nil
+	}
+	items := make([]T, d.len)
+	d.copyTo(items)
+	return items
+}
+
+// index returns the position in the ring buffer of the item that is i places
+// behind the front of the deque. i may be negative.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// grow doubles the capacity of the ring buffer if it is full.
+func (d *Deque[T]) grow() {
+	if d.len < len(d.buf) {
+		return
+	}
+	newCap := 2 * len(d.buf)
+	if newCap == 0 {
+		newCap = minDequeCap
+	}
+	d.resize(newCap)
+}
+
+// shrink halves the capacity of the ring buffer if it is no more than a
+// quarter full, down to minDequeCap.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minDequeCap && d.len <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize moves the items of the deque into a new ring buffer of the given
+// capacity, which must be a power of 2 no smaller than the number of items.
+func (d *Deque[T]) resize(newCap int) {
+	buf := make([]T, newCap)
+	d.copyTo(buf)
+	d.buf = buf
+	d.head = 0
+}
+
+// copyTo copies the items of the deque, from front to back, to the start of
+// dst.
+func (d *Deque[T]) copyTo(dst []T) {
+	if d.len == 0 {
+		return
+	}
+	end := d.head + d.len
+	if end <= len(d.buf) {
+		copy(dst, d.buf[d.head:end])
+		return
+	}
+	n := copy(dst, d.buf[d.head:])
+	copy(dst[n:], d.buf[:end-len(d.buf)])
+}
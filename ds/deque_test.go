@@ -0,0 +1,169 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeque_PushAndPopAtBothEnds(t *testing.T) {
+	t.Parallel()
+	var d ds.Deque[int]
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+	want := []int{0, 1, 2, 3}
+	got := d.Items()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+	front, ok := d.PopFront()
+	if !ok || front != 0 {
+		t.Errorf("want front 0, got %d (ok %t)", front, ok)
+	}
+	back, ok := d.PopBack()
+	if !ok || back != 3 {
+		t.Errorf("want back 3, got %d (ok %t)", back, ok)
+	}
+	if d.Len() != 2 {
+		t.Errorf("want length 2, got %d", d.Len())
+	}
+}
+
+func TestDeque_PeekDoesNotRemoveItems(t *testing.T) {
+	t.Parallel()
+	var d ds.Deque[string]
+	d.PushBack("a")
+	d.PushBack("b")
+	front, ok := d.PeekFront()
+	if !ok || front != "a" {
+		t.Errorf("want front %q, got %q (ok %t)", "a", front, ok)
+	}
+	back, ok := d.PeekBack()
+	if !ok || back != "b" {
+		t.Errorf("want back %q, got %q (ok %t)", "b", back, ok)
+	}
+	if d.Len() != 2 {
+		t.Errorf("want length 2, got %d", d.Len())
+	}
+}
+
+func TestDeque_EmptyDequeReturnsFalse(t *testing.T) {
+	t.Parallel()
+	var d ds.Deque[int]
+	testCases := map[string]func() (int, bool){
+		"PopFront":  d.PopFront,
+		"PopBack":   d.PopBack,
+		"PeekFront": d.PeekFront,
+		"PeekBack":  d.PeekBack,
+	}
+	for name, fn := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := fn(); ok {
+				t.Error("want false, got true")
+			}
+		})
+	}
+	if got := d.Items(); got != nil {
+		t.Errorf("want no items, got %v", got)
+	}
+}
+
+func TestDeque_KeepsOrderAcrossWrapAroundAndGrowth(t *testing.T) {
+	t.Parallel()
+	var d ds.Deque[int]
+	var want []int
+	for i := 0; i < 6; i++ {
+		d.PushBack(i)
+		want = append(want, i)
+	}
+	for i := 0; i < 4; i++ {
+		d.PopFront()
+		want = want[1:]
+	}
+	for i := 6; i < 40; i++ {
+		d.PushBack(i)
+		want = append(want, i)
+	}
+	d.PushFront(-1)
+	want = append([]int{-1}, want...)
+	got := d.Items()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+	for len(want) > 0 {
+		back, ok := d.PopBack()
+		if !ok || back != want[len(want)-1] {
+			t.Fatalf("want back %d, got %d (ok %t)", want[len(want)-1], back, ok)
+		}
+		want = want[:len(want)-1]
+	}
+}
+
+func TestDeque_CapacityShrinksAsItemsAreRemoved(t *testing.T) {
+	t.Parallel()
+	var d ds.Deque[int]
+	for i := 0; i < 1<<16; i++ {
+		d.PushBack(i)
+	}
+	if d.Cap() < 1<<16 {
+		t.Fatalf("want capacity of at least %d, got %d", 1<<16, d.Cap())
+	}
+	for d.Len() > 10 {
+		d.PopFront()
+	}
+	if d.Cap() > 64 {
+		t.Errorf("want capacity of at most 64 for 10 items, got %d", d.Cap())
+	}
+	for d.Len() > 0 {
+		d.PopBack()
+	}
+	if d.Cap() > 8 {
+		t.Errorf("want capacity of at most 8 for an empty deque, got %d", d.Cap())
+	}
+}
+
+func TestDeque_CapacityStaysBoundedInSteadyState(t *testing.T) {
+	t.Parallel()
+	var d ds.Deque[int]
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+	}
+	for i := 0; i < 1_000_000; i++ {
+		v, _ := d.PopFront()
+		d.PushBack(v)
+	}
+	if d.Cap() > 128 {
+		t.Errorf("want capacity of at most 128 for 100 items, got %d", d.Cap())
+	}
+}
+
+func BenchmarkDeque_PushBackPopFront(b *testing.B) {
+	var d ds.Deque[int]
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, _ := d.PopFront()
+		d.PushBack(v)
+	}
+	b.ReportMetric(float64(d.Cap()), "cap")
+}
+
+func BenchmarkDeque_PushFrontPopBack(b *testing.B) {
+	var d ds.Deque[int]
+	for i := 0; i < 100; i++ {
+		d.PushFront(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, _ := d.PopBack()
+		d.PushFront(v)
+	}
+	b.ReportMetric(float64(d.Cap()), "cap")
+}
@@ -0,0 +1,282 @@
+package ds
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aculclasure/aoc2022/textio"
+)
+
+// Grid represents a generic two-dimensional grid of cells addressed by row and
+// column, both starting at 0 in the top left corner. The zero value is an
+// empty grid with no rows and no columns.
+type Grid[T any] struct {
+	rows  int
+	cols  int
+	cells []T
+}
+
+// NewGrid accepts a number of rows and columns and returns a Grid of that size
+// with every cell set to the zero value of T. An error is returned if either
+// dimension is negative.
+func NewGrid[T any](rows, cols int) (*Grid[T], error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("grid dimensions must not be negative (got %dx%d)", rows, cols)
+	}
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}, nil
+}
+
+// GridFromRows accepts a slice of rows of cells and returns a Grid holding a
+// copy of them. An error is returned if the rows are not all the same length.
+func GridFromRows[T any](rows [][]T) (*Grid[T], error) {
+	if len(rows) == 0 {
+		return &Grid[T]{}, nil
+	}
+	g := &Grid[T]{rows: len(rows), cols: len(rows[0])}
+	g.cells = make([]T, 0, g.rows*g.cols)
+	for i, row := range rows {
+		if len(row) != g.cols {
+			return nil, fmt.Errorf("row %d must have %d cells (got %d)", i, g.cols, len(row))
+		}
+		g.cells = append(g.cells, row...)
+	}
+	return g, nil
+}
+
+// ParseGrid accepts line-separated input with one character per cell and a
+// decode function converting a character into a cell value and returns the
+// Grid described by the input. It behaves like ReadGrid.
+func ParseGrid[T any](input []byte, decode func(rune) (T, error)) (*Grid[T], error) {
+	return ReadGrid(bytes.NewReader(input), decode)
+}
+
+// ReadGrid accepts an io.Reader pointing to line-separated input with one
+// character per cell and a decode function converting a character into a cell
+// value and returns the Grid described by the input. Whitespace around each
+// line and blank lines are ignored. An error is returned if decode fails for a
+// character, if the lines are not all the same length, or if there is a
+// problem reading the input. Errors in the input are returned as a
+// *textio.ParseError giving the position of the offending character or line.
+func ReadGrid[T any](r io.Reader, decode func(rune) (T, error), opts ...textio.Option) (*Grid[T], error) {
+	if r == nil {
+		return nil, errors.New("input must be non-nil")
+	}
+	if decode == nil {
+		return nil, errors.New("decode function must be non-nil")
+	}
+	g := &Grid[T]{}
+	scn := textio.NewScanner(r, opts...)
+	for scn.Scan() {
+		line := scn.Text()
+		row := strings.TrimSpace(line)
+		if row == "" {
+			continue
+		}
+		offset := strings.Index(line, row)
+		numCells := 0
+		for i, ch := range row {
+			val, err := decode(ch)
+			if err != nil {
+				return nil, scn.Error(textio.FieldError(offset+i+1, string(ch), err))
+			}
+			g.cells = append(g.cells, val)
+			numCells++
+		}
+		if g.rows == 0 {
+			g.cols = numCells
+		}
+		if numCells != g.cols {
+			return nil, scn.Error(fmt.Errorf("row must have %d cells (got %d)", g.cols, numCells))
+		}
+		g.rows++
+	}
+	if err := scn.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Rows returns the number of rows in the grid.
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns in the grid.
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// InBounds reports whether the given row and column address a cell of the
+// grid.
+func (g *Grid[T]) InBounds(row, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
+
+// Get returns the value of the cell at the given row and column along with a
+// boolean value that is false if the position is outside of the grid.
+func (g *Grid[T]) Get(row, col int) (T, bool) {
+	if !g.InBounds(row, col) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[row*g.cols+col], true
+}
+
+// Set accepts a row, a column and a value T and stores the value in the cell at
+// that position. It returns false, leaving the grid unchanged, if the position
+// is outside of the grid.
+func (g *Grid[T]) Set(row, col int, val T) bool {
+	if !g.InBounds(row, col) {
+		return false
+	}
+	g.cells[row*g.cols+col] = val
+	return true
+}
+
+// Row returns a copy of the cells in the given row, from left to right. Nil is
+// returned if the row is outside of the grid.
+func (g *Grid[T]) Row(row int) []T {
+	if row < 0 || row >= g.rows {
+		return nil
+	}
+	return append([]T(nil), g.cells[row*g.cols:(row+1)*g.cols]...)
+}
+
+// Col returns a copy of the cells in the given column, from top to bottom. Nil
+// is returned if the column is outside of the grid.
+func (g *Grid[T]) Col(col int) []T {
+	if col < 0 || col >= g.cols {
+		return nil
+	}
+	cells := make([]T, g.rows)
+	for row := range cells {
+		cells[row] = g.cells[row*g.cols+col]
+	}
+	return cells
+}
+
+// Each calls fn for every cell of the grid, row by row from the top left
+// corner, until fn returns false.
+func (g *Grid[T]) Each(fn func(row, col int, val T) bool) {
+	for i, val := range g.cells {
+		if !fn(i/g.cols, i%g.cols, val) {
+			return
+		}
+	}
+}
+
+// Neighbors4 calls fn for each cell of the grid sharing an edge with the cell
+// at the given row and column, clockwise starting from the cell above, until
+// fn returns false.
+func (g *Grid[T]) Neighbors4(row, col int, fn func(row, col int, val T) bool) {
+	g.neighbors(row, col, Directions4, fn)
+}
+
+// Neighbors8 calls fn for each cell of the grid sharing an edge or a corner
+// with the cell at the given row and column, clockwise starting from the cell
+// above, until fn returns false.
+func (g *Grid[T]) Neighbors8(row, col int, fn func(row, col int, val T) bool) {
+	g.neighbors(row, col, Directions8, fn)
+}
+
+// neighbors calls fn for each cell of the grid in one of the given directions
+// from the cell at row and col until fn returns false.
+func (g *Grid[T]) neighbors(row, col int, directions []Point, fn func(row, col int, val T) bool) {
+	for _, dir := range directions {
+		r, c := row+dir.Row, col+dir.Col
+		if !g.InBounds(r, c) {
+			continue
+		}
+		if !fn(r, c, g.cells[r*g.cols+c]) {
+			return
+		}
+	}
+}
+
+// Ray calls fn for each cell of the grid on the straight line leaving the given
+// row and column in the direction of rowDelta and colDelta, nearest first,
+// until fn returns false or the line leaves the grid. The starting cell itself
+// is not visited and may lie outside of the grid, such as one step off an edge
+// to look across the grid. Ray does nothing if both deltas are 0.
+func (g *Grid[T]) Ray(row, col, rowDelta, colDelta int, fn func(row, col int, val T) bool) {
+	if rowDelta == 0 && colDelta == 0 {
+		return
+	}
+	for r, c := row+rowDelta, col+colDelta; ; r, c = r+rowDelta, c+colDelta {
+		if !g.InBounds(r, c) {
+			if g.approaching(r, c, rowDelta, colDelta) {
+				continue
+			}
+			return
+		}
+		if !fn(r, c, g.cells[r*g.cols+c]) {
+			return
+		}
+	}
+}
+
+// approaching reports whether a ray at the given position outside of the grid
+// moving by rowDelta and colDelta will still reach the grid.
+func (g *Grid[T]) approaching(row, col, rowDelta, colDelta int) bool {
+	towards := func(pos, delta, size int) bool {
+		switch {
+		case pos < 0:
+			return delta > 0
+		case pos >= size:
+			return delta < 0
+		default:
+			return true
+		}
+	}
+	return towards(row, rowDelta, g.rows) && towards(col, colDelta, g.cols)
+}
+
+// Transpose returns a new Grid with the rows of g as its columns.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.cols, g.rows, func(row, col int) (int, int) {
+		return col, row
+	})
+}
+
+// RotateClockwise returns a new Grid holding g rotated a quarter turn
+// clockwise.
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	return g.remap(g.cols, g.rows, func(row, col int) (int, int) {
+		return col, g.rows - 1 - row
+	})
+}
+
+// RotateCounterclockwise returns a new Grid holding g rotated a quarter turn
+// counterclockwise.
+func (g *Grid[T]) RotateCounterclockwise() *Grid[T] {
+	return g.remap(g.cols, g.rows, func(row, col int) (int, int) {
+		return g.cols - 1 - col, row
+	})
+}
+
+// remap returns a new Grid of the given size holding the cells of g, each
+// moved to the position returned by to.
+func (g *Grid[T]) remap(rows, cols int, to func(row, col int) (int, int)) *Grid[T] {
+	out := &Grid[T]{rows: rows, cols: cols, cells: make([]T, len(g.cells))}
+	for i, val := range g.cells {
+		r, c := to(i/g.cols, i%g.cols)
+		out.cells[r*cols+c] = val
+	}
+	return out
+}
+
+// String returns the grid rendered as text, one line per row with the cells of
+// the row formatted with fmt.Sprint and written next to each other.
+func (g *Grid[T]) String() string {
+	var sb strings.Builder
+	for i, val := range g.cells {
+		if i > 0 && i%g.cols == 0 {
+			sb.WriteByte('\n')
+		}
+		fmt.Fprint(&sb, val)
+	}
+	return sb.String()
+}
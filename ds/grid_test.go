@@ -0,0 +1,341 @@
+package ds_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
+	"github.com/google/go-cmp/cmp"
+)
+
+func digit(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, fmt.Errorf("want a digit, got %q", r)
+	}
+	return int(r - '0'), nil
+}
+
+// mustGrid returns the grid of digits described by input or stops the test.
+func mustGrid(t *testing.T, input string) *ds.Grid[int] {
+	t.Helper()
+	g, err := ds.ParseGrid([]byte(input), digit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// cell is a cell visited by one of the grid iterators.
+type cell struct {
+	Row, Col, Val int
+}
+
+// collect returns a callback for the grid iterators that appends the visited
+// cells to cells.
+func collect(cells *[]cell) func(row, col, val int) bool {
+	return func(row, col, val int) bool {
+		*cells = append(*cells, cell{row, col, val})
+		return true
+	}
+}
+
+func TestParseGrid(t *testing.T) {
+	t.Parallel()
+	g := mustGrid(t, "\n123\r\n456\n\n")
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Fatalf("want a 2x3 grid, got %dx%d", g.Rows(), g.Cols())
+	}
+	want := [][]int{{1, 2, 3}, {4, 5, 6}}
+	got := [][]int{g.Row(0), g.Row(1)}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestParseGridErrorCases(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input    string
+		wantLine int
+		wantCol  int
+	}{
+		"Cell rejected by the decoder reports its position": {
+			input:    "123\n4x6\n",
+			wantLine: 2,
+			wantCol:  2,
+		},
+		"Row of a different length reports the row": {
+			input:    "123\n45\n",
+			wantLine: 2,
+			wantCol:  1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ds.ParseGrid([]byte(tc.input), digit)
+			var pe *textio.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("want a *textio.ParseError, got %v", err)
+			}
+			if pe.Line != tc.wantLine || pe.Column != tc.wantCol {
+				t.Errorf("want error at %d:%d, got %d:%d", tc.wantLine, tc.wantCol, pe.Line, pe.Column)
+			}
+		})
+	}
+}
+
+func TestNewGridWithNegativeDimensionsReturnsError(t *testing.T) {
+	t.Parallel()
+	_, err := ds.NewGrid[int](-1, 2)
+	if err == nil {
+		t.Error("expected an error but did not get one")
+	}
+}
+
+func TestGridFromRowsWithRaggedRowsReturnsError(t *testing.T) {
+	t.Parallel()
+	_, err := ds.GridFromRows([][]int{{1, 2}, {3}})
+	if err == nil {
+		t.Error("expected an error but did not get one")
+	}
+}
+
+func TestGrid_GetAndSetAreBoundsChecked(t *testing.T) {
+	t.Parallel()
+	g, err := ds.NewGrid[string](2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Set(1, 2, "x") {
+		t.Fatal("want true when setting a cell inside the grid, got false")
+	}
+	got, ok := g.Get(1, 2)
+	if !ok || got != "x" {
+		t.Errorf("want %q, got %q (ok %t)", "x", got, ok)
+	}
+	for _, pos := range [][2]int{{-1, 0}, {0, -1}, {2, 0}, {0, 3}} {
+		if g.Set(pos[0], pos[1], "y") {
+			t.Errorf("want false when setting cell %v outside the grid, got true", pos)
+		}
+		if _, ok := g.Get(pos[0], pos[1]); ok {
+			t.Errorf("want false when getting cell %v outside the grid, got true", pos)
+		}
+	}
+}
+
+func TestGrid_RowAndCol(t *testing.T) {
+	t.Parallel()
+	g := mustGrid(t, "123\n456\n")
+	testCases := map[string]struct {
+		got  []int
+		want []int
+	}{
+		"Row returns cells from left to right": {
+			got:  g.Row(1),
+			want: []int{4, 5, 6},
+		},
+		"Col returns cells from top to bottom": {
+			got:  g.Col(2),
+			want: []int{3, 6},
+		},
+		"Row outside the grid returns nil": {
+			got:  g.Row(2),
+			want: nil,
+		},
+		"Col outside the grid returns nil": {
+			got:  g.Col(-1),
+			want: nil,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if !cmp.Equal(tc.want, tc.got) {
+				t.Error(cmp.Diff(tc.want, tc.got))
+			}
+		})
+	}
+}
+
+func TestGrid_Each(t *testing.T) {
+	t.Parallel()
+	g := mustGrid(t, "12\n34\n")
+	var got []cell
+	g.Each(collect(&got))
+	want := []cell{{0, 0, 1}, {0, 1, 2}, {1, 0, 3}, {1, 1, 4}}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestGrid_Neighbors(t *testing.T) {
+	t.Parallel()
+	g := mustGrid(t, "123\n456\n789\n")
+	testCases := map[string]struct {
+		each func(row, col int, fn func(row, col, val int) bool)
+		row  int
+		col  int
+		want []cell
+	}{
+		"Neighbors4 of a middle cell visits all 4 neighbours clockwise": {
+			each: g.Neighbors4,
+			row:  1,
+			col:  1,
+			want: []cell{{0, 1, 2}, {1, 2, 6}, {2, 1, 8}, {1, 0, 4}},
+		},
+		"Neighbors4 of a corner cell skips cells outside the grid": {
+			each: g.Neighbors4,
+			row:  0,
+			col:  0,
+			want: []cell{{0, 1, 2}, {1, 0, 4}},
+		},
+		"Neighbors8 of a middle cell visits all 8 neighbours clockwise": {
+			each: g.Neighbors8,
+			row:  1,
+			col:  1,
+			want: []cell{{0, 1, 2}, {0, 2, 3}, {1, 2, 6}, {2, 2, 9}, {2, 1, 8}, {2, 0, 7}, {1, 0, 4}, {0, 0, 1}},
+		},
+		"Neighbors8 of an edge cell skips cells outside the grid": {
+			each: g.Neighbors8,
+			row:  2,
+			col:  1,
+			want: []cell{{1, 1, 5}, {1, 2, 6}, {2, 2, 9}, {2, 0, 7}, {1, 0, 4}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got []cell
+			tc.each(tc.row, tc.col, collect(&got))
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestGrid_Ray(t *testing.T) {
+	t.Parallel()
+	g := mustGrid(t, "123\n456\n789\n")
+	testCases := map[string]struct {
+		row, col, rowDelta, colDelta int
+		want                         []cell
+	}{
+		"Ray to the right visits the rest of the row": {
+			row: 1, col: 0, rowDelta: 0, colDelta: 1,
+			want: []cell{{1, 1, 5}, {1, 2, 6}},
+		},
+		"Ray up visits the cells above": {
+			row: 2, col: 2, rowDelta: -1, colDelta: 0,
+			want: []cell{{1, 2, 6}, {0, 2, 3}},
+		},
+		"Diagonal ray visits the diagonal": {
+			row: 0, col: 0, rowDelta: 1, colDelta: 1,
+			want: []cell{{1, 1, 5}, {2, 2, 9}},
+		},
+		"Ray from outside the grid looks across it": {
+			row: -1, col: 1, rowDelta: 1, colDelta: 0,
+			want: []cell{{0, 1, 2}, {1, 1, 5}, {2, 1, 8}},
+		},
+		"Ray starting at the edge and leaving the grid visits nothing": {
+			row: 0, col: 2, rowDelta: 0, colDelta: 1,
+			want: nil,
+		},
+		"Ray passing beside the grid visits nothing": {
+			row: -1, col: 0, rowDelta: 0, colDelta: 1,
+			want: nil,
+		},
+		"Ray without a direction visits nothing": {
+			row: 1, col: 1,
+			want: nil,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got []cell
+			g.Ray(tc.row, tc.col, tc.rowDelta, tc.colDelta, collect(&got))
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestGrid_IteratorsStopWhenCallbackReturnsFalse(t *testing.T) {
+	t.Parallel()
+	g := mustGrid(t, "123\n456\n789\n")
+	visits := 0
+	stop := func(_, _, _ int) bool {
+		visits++
+		return false
+	}
+	g.Each(stop)
+	g.Neighbors4(1, 1, stop)
+	g.Neighbors8(1, 1, stop)
+	g.Ray(1, 1, 0, 1, stop)
+	if visits != 4 {
+		t.Errorf("want each iterator to stop after 1 visit (4 in total), got %d", visits)
+	}
+}
+
+func TestGrid_TransposeAndRotate(t *testing.T) {
+	t.Parallel()
+	g := mustGrid(t, "123\n456\n")
+	testCases := map[string]struct {
+		got  *ds.Grid[int]
+		want string
+	}{
+		"Transpose swaps rows and columns": {
+			got:  g.Transpose(),
+			want: "14\n25\n36",
+		},
+		"RotateClockwise turns the grid a quarter turn right": {
+			got:  g.RotateClockwise(),
+			want: "41\n52\n63",
+		},
+		"RotateCounterclockwise turns the grid a quarter turn left": {
+			got:  g.RotateCounterclockwise(),
+			want: "36\n25\n14",
+		},
+		"Four clockwise rotations restore the grid": {
+			got:  g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise(),
+			want: "123\n456",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.got.String()
+			if tc.want != got {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGrid_StringRendersCells(t *testing.T) {
+	t.Parallel()
+	g, err := ds.GridFromRows([][]string{{"#", "."}, {".", "#"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "#.\n.#"
+	if got := g.String(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	var empty ds.Grid[int]
+	if got := empty.String(); got != "" {
+		t.Errorf("want empty grid to render as an empty string, got %q", got)
+	}
+}
+
+func TestGrid_StringUsesFmtForOtherTypes(t *testing.T) {
+	t.Parallel()
+	g, err := ds.GridFromRows([][]int{{1, 10}, {100, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "110\n1002"
+	if got := g.String(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,148 @@
+package ds
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Point represents a position or an offset on a two-dimensional grid, given by
+// a row and a column.
+type Point struct {
+	Row int
+	Col int
+}
+
+// The directions on a grid as unit offsets, with rows growing downwards and
+// columns growing to the right as in Grid.
+var (
+	Up        = Point{Row: -1}
+	Down      = Point{Row: 1}
+	Left      = Point{Col: -1}
+	Right     = Point{Col: 1}
+	UpLeft    = Up.Add(Left)
+	UpRight   = Up.Add(Right)
+	DownLeft  = Down.Add(Left)
+	DownRight = Down.Add(Right)
+)
+
+// Directions4 holds the directions to the cells sharing an edge with a cell,
+// clockwise starting from Up.
+var Directions4 = []Point{Up, Right, Down, Left}
+
+// Directions8 holds the directions to the cells sharing an edge or a corner
+// with a cell, clockwise starting from Up.
+var Directions8 = []Point{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+
+// Add returns the sum of p and q.
+func (p Point) Add(q Point) Point {
+	return Point{Row: p.Row + q.Row, Col: p.Col + q.Col}
+}
+
+// Sub returns the difference of p and q.
+func (p Point) Sub(q Point) Point {
+	return Point{Row: p.Row - q.Row, Col: p.Col - q.Col}
+}
+
+// Scale returns p with both coordinates multiplied by k.
+func (p Point) Scale(k int) Point {
+	return Point{Row: p.Row * k, Col: p.Col * k}
+}
+
+// Sign returns p with each coordinate replaced by -1, 0 or 1 according to its
+// sign, which turns an offset into a single step in the same direction.
+func (p Point) Sign() Point {
+	return Point{Row: sign(p.Row), Col: sign(p.Col)}
+}
+
+// Manhattan returns the Manhattan distance between p and q, the number of
+// steps between them when moving only along rows and columns.
+func (p Point) Manhattan(q Point) int {
+	d := p.Sub(q)
+	return abs(d.Row) + abs(d.Col)
+}
+
+// Chebyshev returns the Chebyshev distance between p and q, the number of
+// steps between them when diagonal moves are also allowed.
+func (p Point) Chebyshev(q Point) int {
+	d := p.Sub(q)
+	return maxInt(abs(d.Row), abs(d.Col))
+}
+
+// Less reports whether p comes before q in row-major order, which sorts points
+// row by row from the top left corner.
+func (p Point) Less(q Point) bool {
+	if p.Row != q.Row {
+		return p.Row < q.Row
+	}
+	return p.Col < q.Col
+}
+
+// String returns p in the form "row,col".
+func (p Point) String() string {
+	return strconv.Itoa(p.Row) + "," + strconv.Itoa(p.Col)
+}
+
+// MarshalText implements encoding.TextMarshaler. The text form of a Point is
+// "row,col", which also lets points be used as JSON object keys.
+func (p Point) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the text form
+// written by MarshalText.
+func (p *Point) UnmarshalText(text []byte) error {
+	parsed, err := ParsePoint(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// ParsePoint accepts a string in the form "row,col" and returns the Point it
+// describes. Whitespace around either coordinate is ignored. An error is
+// returned if s is not in that form.
+func ParsePoint(s string) (Point, error) {
+	rowText, colText, ok := strings.Cut(s, ",")
+	if !ok {
+		return Point{}, fmt.Errorf(`point must be in the form "row,col" (got %q)`, s)
+	}
+	row, err := strconv.Atoi(strings.TrimSpace(rowText))
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid row in point %q: %w", s, err)
+	}
+	col, err := strconv.Atoi(strings.TrimSpace(colText))
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid column in point %q: %w", s, err)
+	}
+	return Point{Row: row, Col: col}, nil
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// maxInt returns the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// sign returns -1, 0 or 1 according to the sign of x.
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
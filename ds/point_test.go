@@ -0,0 +1,138 @@
+package ds_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPoint_Arithmetic(t *testing.T) {
+	t.Parallel()
+	p := ds.Point{Row: 2, Col: -3}
+	q := ds.Point{Row: -1, Col: 5}
+	testCases := map[string]struct {
+		got  ds.Point
+		want ds.Point
+	}{
+		"Add sums the coordinates": {
+			got:  p.Add(q),
+			want: ds.Point{Row: 1, Col: 2},
+		},
+		"Sub subtracts the coordinates": {
+			got:  p.Sub(q),
+			want: ds.Point{Row: 3, Col: -8},
+		},
+		"Scale multiplies the coordinates": {
+			got:  p.Scale(-2),
+			want: ds.Point{Row: -4, Col: 6},
+		},
+		"Sign reduces the coordinates to a single step": {
+			got:  ds.Point{Row: 7, Col: -4}.Sign(),
+			want: ds.DownLeft,
+		},
+		"Sign keeps zero coordinates": {
+			got:  ds.Point{Col: 3}.Sign(),
+			want: ds.Right,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.want != tc.got {
+				t.Errorf("want %v, got %v", tc.want, tc.got)
+			}
+		})
+	}
+}
+
+func TestPoint_Distances(t *testing.T) {
+	t.Parallel()
+	p := ds.Point{Row: 1, Col: 1}
+	q := ds.Point{Row: -2, Col: 5}
+	if got := p.Manhattan(q); got != 7 {
+		t.Errorf("want Manhattan distance 7, got %d", got)
+	}
+	if got := p.Chebyshev(q); got != 4 {
+		t.Errorf("want Chebyshev distance 4, got %d", got)
+	}
+	if got := q.Manhattan(p); got != 7 {
+		t.Errorf("want Manhattan distance to be symmetric, got %d", got)
+	}
+}
+
+func TestPoint_DirectionsAreUnitSteps(t *testing.T) {
+	t.Parallel()
+	origin := ds.Point{}
+	for _, dir := range ds.Directions8 {
+		if got := origin.Chebyshev(dir); got != 1 {
+			t.Errorf("want direction %v to be a single step, got distance %d", dir, got)
+		}
+	}
+	for _, dir := range ds.Directions4 {
+		if got := origin.Manhattan(dir); got != 1 {
+			t.Errorf("want direction %v to move along a row or column, got distance %d", dir, got)
+		}
+	}
+	if ds.Up.Add(ds.Down) != origin || ds.Left.Add(ds.Right) != origin {
+		t.Error("want opposite directions to cancel out")
+	}
+}
+
+func TestPoint_Less(t *testing.T) {
+	t.Parallel()
+	s := ds.NewSet(ds.Point{Row: 1, Col: 0}, ds.Point{Row: 0, Col: 2}, ds.Point{Row: 0, Col: -1})
+	want := []ds.Point{{Row: 0, Col: -1}, {Row: 0, Col: 2}, {Row: 1, Col: 0}}
+	got := s.Sorted(ds.Point.Less)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestPoint_TextMarshalling(t *testing.T) {
+	t.Parallel()
+	visits := map[ds.Point]int{{Row: 0, Col: 0}: 1, {Row: -1, Col: 2}: 3}
+	data, err := json.Marshal(visits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"-1,2":3,"0,0":1}`
+	if string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+	var got map[ds.Point]int
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(visits, got) {
+		t.Error(cmp.Diff(visits, got))
+	}
+}
+
+func TestParsePoint(t *testing.T) {
+	t.Parallel()
+	got, err := ds.ParsePoint(" 3, -4 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ds.Point{Row: 3, Col: -4}); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestParsePointErrorCases(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]string{
+		"Point without a comma returns error":          "3 4",
+		"Point with a non-numerical row returns error": "a,4",
+		"Point with a non-numerical col returns error": "3,b",
+	}
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ds.ParsePoint(input)
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}
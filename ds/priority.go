@@ -0,0 +1,172 @@
+package ds
+
+// Handle refers to an item pushed onto a PriorityQueue. It is used to update
+// or remove the item while it is in the queue.
+type Handle[T any] struct {
+	val T
+	// index is the position of the item in the heap, or -1 once the item has
+	// left the queue.
+	index int
+}
+
+// Value returns the value of the item referred to by h.
+func (h *Handle[T]) Value() T {
+	return h.val
+}
+
+// PriorityQueue represents a generic priority queue ordered by a less
+// function: the item for which less reports true against every other item is
+// at the front of the queue. Push, Pop, Update and Remove take logarithmic
+// time and Peek constant time. A PriorityQueue is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	less  func(a, b T) bool
+	items []*Handle[T]
+}
+
+// NewPriorityQueue accepts a less function and returns an empty PriorityQueue
+// ordered by it. For a min-queue less should report whether a is smaller than
+// b, for a max-queue whether a is larger than b.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// NewPriorityQueueFromItems accepts a less function and a list of items and
+// returns a PriorityQueue holding the items ordered by less. The queue is
+// built in linear time.
+func NewPriorityQueueFromItems[T any](less func(a, b T) bool, items ...T) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{
+		less:  less,
+		items: make([]*Handle[T], len(items)),
+	}
+	for i, v := range items {
+		pq.items[i] = &Handle[T]{val: v, index: i}
+	}
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	return pq
+}
+
+// Push accepts a value T, adds it to the queue and returns a Handle that can
+// be used to update or remove it.
+func (pq *PriorityQueue[T]) Push(val T) *Handle[T] {
+	h := &Handle[T]{val: val, index: len(pq.items)}
+	pq.items = append(pq.items, h)
+	pq.up(h.index)
+	return h
+}
+
+// Pop removes and returns the item at the front of the queue along with a
+// boolean value indicating if the Pop was successful. The boolean value will
+// be false when attempting to pop from an empty queue.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.remove(0), true
+}
+
+// Peek returns the item at the front of the queue without removing it, along
+// with a boolean value that is false if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0].val, true
+}
+
+// Len returns the number of items in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Update accepts the Handle of an item in the queue and a new value for the
+// item and moves the item to its new position in the queue, as needed to
+// decrease or increase its priority. It returns false if the item is no longer
+// in the queue.
+func (pq *PriorityQueue[T]) Update(h *Handle[T], val T) bool {
+	if !pq.contains(h) {
+		return false
+	}
+	h.val = val
+	if !pq.down(h.index) {
+		pq.up(h.index)
+	}
+	return true
+}
+
+// Remove accepts the Handle of an item in the queue, removes the item and
+// returns its value along with a boolean value that is false if the item was
+// no longer in the queue.
+func (pq *PriorityQueue[T]) Remove(h *Handle[T]) (T, bool) {
+	if !pq.contains(h) {
+		var zero T
+		return zero, false
+	}
+	return pq.remove(h.index), true
+}
+
+// contains reports whether h refers to an item in the queue.
+func (pq *PriorityQueue[T]) contains(h *Handle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(pq.items) && pq.items[h.index] == h
+}
+
+// remove removes the item at index i of the heap and returns its value.
+func (pq *PriorityQueue[T]) remove(i int) T {
+	h := pq.items[i]
+	last := len(pq.items) - 1
+	if i != last {
+		pq.swap(i, last)
+	}
+	pq.items[last] = nil
+	pq.items = pq.items[:last]
+	if i != last && !pq.down(i) {
+		pq.up(i)
+	}
+	h.index = -1
+	return h.val
+}
+
+// up moves the item at index i of the heap towards the root until its parent
+// is not ordered after it.
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i].val, pq.items[parent].val) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at index i of the heap towards the leaves until none of
+// its children is ordered before it. It reports whether the item moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.items)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.items[right].val, pq.items[child].val) {
+			child = right
+		}
+		if !pq.less(pq.items[child].val, pq.items[i].val) {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// swap exchanges the items at indexes i and j of the heap.
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
@@ -0,0 +1,166 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/google/go-cmp/cmp"
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+// popAll pops every item from pq and returns them in the order they were
+// popped.
+func popAll[T any](pq *ds.PriorityQueue[T]) []T {
+	var items []T
+	for pq.Len() > 0 {
+		v, _ := pq.Pop()
+		items = append(items, v)
+	}
+	return items
+}
+
+func TestPriorityQueue_PopReturnsItemsInPriorityOrder(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		less func(a, b int) bool
+		want []int
+	}{
+		"Min-queue pops smallest items first": {
+			less: intLess,
+			want: []int{1, 2, 3, 5, 5, 8, 9},
+		},
+		"Max-queue pops largest items first": {
+			less: func(a, b int) bool { return a > b },
+			want: []int{9, 8, 5, 5, 3, 2, 1},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pq := ds.NewPriorityQueue(tc.less)
+			for _, v := range []int{5, 3, 9, 1, 5, 8, 2} {
+				pq.Push(v)
+			}
+			got := popAll(pq)
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestNewPriorityQueueFromItemsHeapifiesItems(t *testing.T) {
+	t.Parallel()
+	items := []int{7, 4, 10, 1, 3, 9, 2, 8, 6, 5}
+	pq := ds.NewPriorityQueueFromItems(intLess, items...)
+	if pq.Len() != len(items) {
+		t.Fatalf("want length %d, got %d", len(items), pq.Len())
+	}
+	want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	got := popAll(pq)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestPriorityQueue_PeekDoesNotRemoveItem(t *testing.T) {
+	t.Parallel()
+	pq := ds.NewPriorityQueueFromItems(intLess, 3, 1, 2)
+	got, ok := pq.Peek()
+	if !ok || got != 1 {
+		t.Errorf("want 1, got %d (ok %t)", got, ok)
+	}
+	if pq.Len() != 3 {
+		t.Errorf("want length 3, got %d", pq.Len())
+	}
+}
+
+func TestPriorityQueue_EmptyQueueReturnsFalse(t *testing.T) {
+	t.Parallel()
+	pq := ds.NewPriorityQueue(intLess)
+	if _, ok := pq.Pop(); ok {
+		t.Error("want false from Pop, got true")
+	}
+	if _, ok := pq.Peek(); ok {
+		t.Error("want false from Peek, got true")
+	}
+}
+
+func TestPriorityQueue_UpdateMovesItem(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		newVal int
+		want   []int
+	}{
+		"Decreasing a key moves the item towards the front": {
+			newVal: 0,
+			want:   []int{0, 1, 2, 4, 5},
+		},
+		"Increasing a key moves the item towards the back": {
+			newVal: 9,
+			want:   []int{1, 2, 4, 5, 9},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pq := ds.NewPriorityQueue(intLess)
+			for _, v := range []int{4, 1, 5, 2} {
+				pq.Push(v)
+			}
+			h := pq.Push(3)
+			if !pq.Update(h, tc.newVal) {
+				t.Fatal("want true from Update, got false")
+			}
+			if h.Value() != tc.newVal {
+				t.Errorf("want handle value %d, got %d", tc.newVal, h.Value())
+			}
+			got := popAll(pq)
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_RemoveTakesItemOutOfQueue(t *testing.T) {
+	t.Parallel()
+	pq := ds.NewPriorityQueue(intLess)
+	handles := map[int]*ds.Handle[int]{}
+	for _, v := range []int{6, 2, 8, 4, 1, 7} {
+		handles[v] = pq.Push(v)
+	}
+	got, ok := pq.Remove(handles[4])
+	if !ok || got != 4 {
+		t.Errorf("want 4, got %d (ok %t)", got, ok)
+	}
+	if _, ok := pq.Remove(handles[4]); ok {
+		t.Error("want false when removing an item twice, got true")
+	}
+	if pq.Update(handles[4], 0) {
+		t.Error("want false when updating a removed item, got true")
+	}
+	want := []int{1, 2, 6, 7, 8}
+	gotItems := popAll(pq)
+	if !cmp.Equal(want, gotItems) {
+		t.Error(cmp.Diff(want, gotItems))
+	}
+	if _, ok := pq.Remove(handles[1]); ok {
+		t.Error("want false when removing a popped item, got true")
+	}
+}
+
+func TestPriorityQueue_HandleFromAnotherQueueIsRejected(t *testing.T) {
+	t.Parallel()
+	pq := ds.NewPriorityQueue(intLess)
+	pq.Push(1)
+	other := ds.NewPriorityQueue(intLess)
+	h := other.Push(2)
+	if pq.Update(h, 0) {
+		t.Error("want false when updating a handle from another queue, got true")
+	}
+	if _, ok := pq.Remove(h); ok {
+		t.Error("want false when removing a handle from another queue, got true")
+	}
+}
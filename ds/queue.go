@@ -2,51 +2,52 @@ package ds
 
 import "sync"
 
+// Queue represents a generic first-in, first-out queue that is safe for
+// concurrent use. It is backed by a Deque, so the memory it holds is
+// proportional to the number of items in the queue.
 type Queue[T any] struct {
-	mtx  *sync.Mutex
-	vals []T
+	mtx   sync.Mutex
+	deque Deque[T]
 }
 
+// Enqueue accepts a value T and adds it to the back of the queue.
 func (q *Queue[T]) Enqueue(val T) {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
-	q.vals = append(q.vals, val)
+	q.deque.PushBack(val)
 }
 
+// Dequeue removes and returns the item at the front of the queue along with a
+// boolean value indicating if the Dequeue was successful. The boolean value
+// will be false when attempting to dequeue from an empty queue.
 func (q *Queue[T]) Dequeue() (T, bool) {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
-	if len(q.vals) == 0 {
-		var zero T
-		return zero, false
-	}
-	front := q.vals[0]
-	q.vals = q.vals[1:]
-	return front, true
+	return q.deque.PopFront()
 }
 
+// Size returns the number of items in the queue.
 func (q *Queue[T]) Size() int {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
-	return len(q.vals)
+	return q.deque.Len()
 }
 
-// PeekItems returns a slice of all items contained in the queue without
+// PeekAllItems returns a slice of all items contained in the queue without
 // removing these items from the queue.
 func (q *Queue[T]) PeekAllItems() []T {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
-	var items []T
-	items = append(items, q.vals...)
-	return items
+	return q.deque.Items()
 }
 
+// NewQueue returns an empty Queue.
 func NewQueue[T any]() *Queue[T] {
-	return &Queue[T]{
-		mtx: new(sync.Mutex),
-	}
+	return &Queue[T]{}
 }
 
+// NewQueueFromItems accepts a list of items and returns a Queue holding them,
+// with the first item at the front of the queue.
 func NewQueueFromItems[T any](items ...T) *Queue[T] {
 	q := NewQueue[T]()
 	for _, v := range items {
@@ -58,3 +58,27 @@ func TestQueue_EnqueueIncreasesQueueSize(t *testing.T) {
 		t.Errorf("want %d, got %d", want, got)
 	}
 }
+
+func TestQueue_SteadyStateEnqueueAndDequeueDoNotAllocate(t *testing.T) {
+	q := ds.NewQueueFromItems(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	allocs := testing.AllocsPerRun(100_000, func() {
+		v, _ := q.Dequeue()
+		q.Enqueue(v)
+	})
+	if allocs != 0 {
+		t.Errorf("want no allocations per enqueue and dequeue, got %v", allocs)
+	}
+}
+
+func BenchmarkQueue_EnqueueDequeue(b *testing.B) {
+	q := ds.NewQueue[int]()
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, _ := q.Dequeue()
+		q.Enqueue(v)
+	}
+}
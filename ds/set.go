@@ -0,0 +1,146 @@
+package ds
+
+import "sort"
+
+// Ordered is a constraint that permits any type whose values can be compared
+// with the < operator.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Set represents a generic set of unique values. The zero value is an empty
+// set ready to use. A Set is not safe for concurrent use.
+type Set[T comparable] struct {
+	vals map[T]struct{}
+}
+
+// NewSet accepts a list of items and returns a Set holding each of them once.
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{vals: make(map[T]struct{}, len(items))}
+	for _, v := range items {
+		s.vals[v] = struct{}{}
+	}
+	return s
+}
+
+// Add accepts a value T and adds it to the set. It reports whether the value
+// was added, which is false if the set already held it.
+func (s *Set[T]) Add(val T) bool {
+	if _, ok := s.vals[val]; ok {
+		return false
+	}
+	if s.vals == nil {
+		s.vals = map[T]struct{}{}
+	}
+	s.vals[val] = struct{}{}
+	return true
+}
+
+// Has accepts a value T and reports whether the set holds it.
+func (s *Set[T]) Has(val T) bool {
+	_, ok := s.vals[val]
+	return ok
+}
+
+// Remove accepts a value T and removes it from the set. It reports whether the
+// set held the value.
+func (s *Set[T]) Remove(val T) bool {
+	if _, ok := s.vals[val]; !ok {
+		return false
+	}
+	delete(s.vals, val)
+	return true
+}
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int {
+	return len(s.vals)
+}
+
+// Union accepts another set and returns a new set holding the values found in
+// either s or other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	u := &Set[T]{vals: make(map[T]struct{}, len(s.vals)+len(other.vals))}
+	for v := range s.vals {
+		u.vals[v] = struct{}{}
+	}
+	for v := range other.vals {
+		u.vals[v] = struct{}{}
+	}
+	return u
+}
+
+// Intersect accepts another set and returns a new set holding the values found
+// in both s and other.
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if large.Len() < small.Len() {
+		small, large = large, small
+	}
+	i := &Set[T]{vals: map[T]struct{}{}}
+	for v := range small.vals {
+		if large.Has(v) {
+			i.vals[v] = struct{}{}
+		}
+	}
+	return i
+}
+
+// Difference accepts another set and returns a new set holding the values of
+// s that are not found in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	d := &Set[T]{vals: map[T]struct{}{}}
+	for v := range s.vals {
+		if !other.Has(v) {
+			d.vals[v] = struct{}{}
+		}
+	}
+	return d
+}
+
+// IsSubset accepts another set and reports whether every value of s is also
+// found in other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for v := range s.vals {
+		if !other.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Items returns a slice of the values in the set in no particular order. Nil
+// is returned for an empty set.
+func (s *Set[T]) Items() []T {
+	if len(s.vals) == 0 {
+		return nil
+	}
+	items := make([]T, 0, len(s.vals))
+	for v := range s.vals {
+		items = append(items, v)
+	}
+	return items
+}
+
+// Sorted accepts a less function and returns a slice of the values in the set
+// sorted by it. Nil is returned for an empty set.
+func (s *Set[T]) Sorted(less func(a, b T) bool) []T {
+	items := s.Items()
+	sort.Slice(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	return items
+}
+
+// SortedItems accepts a Set of ordered values and returns a slice of its
+// values in ascending order. Nil is returned for an empty set.
+func SortedItems[T Ordered](s *Set[T]) []T {
+	return s.Sorted(func(a, b T) bool {
+		return a < b
+	})
+}
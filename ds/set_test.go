@@ -0,0 +1,133 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSet_AddHasRemove(t *testing.T) {
+	t.Parallel()
+	var s ds.Set[string]
+	if s.Has("a") {
+		t.Error("want empty set not to hold a")
+	}
+	if !s.Add("a") {
+		t.Error("want true when adding a new value, got false")
+	}
+	if s.Add("a") {
+		t.Error("want false when adding a value twice, got true")
+	}
+	s.Add("b")
+	if !s.Has("a") || !s.Has("b") {
+		t.Error("want set to hold a and b")
+	}
+	if s.Len() != 2 {
+		t.Errorf("want length 2, got %d", s.Len())
+	}
+	if !s.Remove("a") {
+		t.Error("want true when removing a held value, got false")
+	}
+	if s.Remove("a") {
+		t.Error("want false when removing a value twice, got true")
+	}
+	if s.Has("a") || s.Len() != 1 {
+		t.Errorf("want set holding only b, got %v", s.Items())
+	}
+}
+
+func TestNewSetIgnoresDuplicateItems(t *testing.T) {
+	t.Parallel()
+	s := ds.NewSet(3, 1, 3, 2, 1)
+	want := []int{1, 2, 3}
+	got := ds.SortedItems(s)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestSet_Algebra(t *testing.T) {
+	t.Parallel()
+	a := ds.NewSet(1, 2, 3, 4)
+	b := ds.NewSet(3, 4, 5)
+	testCases := map[string]struct {
+		got  *ds.Set[int]
+		want []int
+	}{
+		"Union holds values of both sets": {
+			got:  a.Union(b),
+			want: []int{1, 2, 3, 4, 5},
+		},
+		"Intersect holds values common to both sets": {
+			got:  a.Intersect(b),
+			want: []int{3, 4},
+		},
+		"Difference holds values of the first set missing from the second": {
+			got:  a.Difference(b),
+			want: []int{1, 2},
+		},
+		"Intersect with an empty set is empty": {
+			got:  a.Intersect(&ds.Set[int]{}),
+			want: nil,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := ds.SortedItems(tc.got)
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+	if want := []int{1, 2, 3, 4}; !cmp.Equal(want, ds.SortedItems(a)) {
+		t.Errorf("want set operations to leave the receiver unchanged, got %v", ds.SortedItems(a))
+	}
+}
+
+func TestSet_IsSubset(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		s, other *ds.Set[rune]
+		want     bool
+	}{
+		"Set with values all found in other is a subset": {
+			s:     ds.NewSet('a', 'b'),
+			other: ds.NewSet('a', 'b', 'c'),
+			want:  true,
+		},
+		"Equal sets are subsets of each other": {
+			s:     ds.NewSet('a', 'b'),
+			other: ds.NewSet('b', 'a'),
+			want:  true,
+		},
+		"Empty set is a subset of any set": {
+			s:     &ds.Set[rune]{},
+			other: ds.NewSet('a'),
+			want:  true,
+		},
+		"Set with a value missing from other is not a subset": {
+			s:     ds.NewSet('a', 'd'),
+			other: ds.NewSet('a', 'b', 'c'),
+			want:  false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.s.IsSubset(tc.other)
+			if tc.want != got {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSet_SortedUsesLessFunction(t *testing.T) {
+	t.Parallel()
+	s := ds.NewSet("bb", "a", "ccc")
+	want := []string{"ccc", "bb", "a"}
+	got := s.Sorted(func(a, b string) bool { return len(a) > len(b) })
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -1,20 +1,21 @@
 package elf
 
 import (
-	"bufio"
 	"errors"
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 // TopCaloryCounts accepts an io.Reader pointing to a data set of how many
 // calories are carried by each elf and returns a slice of ints representing
 // the top 3 calory counts in ascending order. An error is returned if there
-// is a problem reading the data set.
-func TopCaloryCounts(data io.Reader) ([]int, error) {
+// is a problem reading the data set. The options are passed on to ReadData.
+func TopCaloryCounts(data io.Reader, opts ...textio.Option) ([]int, error) {
 	stats := NewCaloryStats()
-	err := stats.ReadData(data)
+	err := stats.ReadData(data, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -44,40 +45,32 @@ func (c *CaloryStats) Insert(caloryCount int) {
 }
 
 // ReadData accepts an io.Reader pointing to a data set of how many
-// calories are carried by each elf, parses it, and fills the HighestCounts
-// slice in the receiver with the parsed data. An error is returned if there is
-// a problem reading from the data source.
-func (c *CaloryStats) ReadData(data io.Reader) error {
+// calories are carried by each elf, with the counts of each elf separated by a
+// blank line, parses it, and fills the HighestCounts slice in the receiver
+// with the parsed data. An error is returned if there is a problem reading
+// from the data source. A calorie count that is not a valid integer is
+// reported as a *textio.ParseError giving its position.
+func (c *CaloryStats) ReadData(data io.Reader, opts ...textio.Option) error {
 	if data == nil {
 		return errors.New("data must point to a non-nil data source")
 	}
-	rdr := bufio.NewReader(data)
-	currentElfCalories := 0
-	for {
-		line, err := rdr.ReadString('\n')
-		if err != nil && err == io.EOF {
-			c.Insert(currentElfCalories)
-			break
-		}
-		if err != nil {
-			return err
+	blocks := textio.NewBlockScanner(data, opts...)
+	for blocks.Scan() {
+		elfCalories := 0
+		for i, line := range blocks.Block().Lines {
+			numCalories, err := strconv.Atoi(strings.TrimSpace(line))
+			if err != nil {
+				if err := blocks.Skip(i, err); err != nil {
+					return err
+				}
+				continue
+			}
+			elfCalories += numCalories
 		}
-
-		line = strings.TrimSpace(line)
-		if line == "" {
-			c.Insert(currentElfCalories)
-			currentElfCalories = 0
-			continue
-		}
-		numCalories, err := strconv.Atoi(line)
-		if err != nil {
-			return err
-		}
-		currentElfCalories += numCalories
-
+		c.Insert(elfCalories)
 	}
 
-	return nil
+	return blocks.Err()
 }
 
 // NewCaloryStats returns a CaloryStats struct with an initialized HighestCounts
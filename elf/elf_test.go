@@ -76,3 +76,16 @@ func TestCaloryStats_Insert(t *testing.T) {
 		})
 	}
 }
+
+func TestTopCaloryCountsWithWindowsSavedInput(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader("\ufeff1000\r\n2000\r\n\r\n4000\r\n\r\n5000\r\n6000\r\n\r\n7000\r\n")
+	got, err := elf.TopCaloryCounts(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{11000, 7000, 4000}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
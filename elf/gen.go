@@ -0,0 +1,94 @@
+package elf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// GenerateCalories writes a random calorie list for numElves elves to w. Each
+// elf carries between 1 and 15 food items of 1000 to 60000 calories and the
+// elves are separated by blank lines. An error is returned if numElves is
+// smaller than 1 or if there is a problem writing to w.
+func GenerateCalories(w io.Writer, rng *rand.Rand, numElves int) error {
+	if numElves < 1 {
+		return fmt.Errorf("number of elves must be at least 1 (got %d)", numElves)
+	}
+	bw := bufio.NewWriter(w)
+	for i := 0; i < numElves; i++ {
+		if i > 0 {
+			bw.WriteByte('\n')
+		}
+		numItems := 1 + rng.Intn(15)
+		for j := 0; j < numItems; j++ {
+			fmt.Fprintln(bw, 1000+rng.Intn(59001))
+		}
+	}
+	return bw.Flush()
+}
+
+// GenerateRucksacks writes the contents of the rucksacks for numGroups random
+// groups of three elves to w. Each rucksack has exactly one item type in both
+// of its compartments and each group has exactly one item type (its badge)
+// that is carried by all three elves. An error is returned if numGroups is
+// smaller than 1 or if there is a problem writing to w.
+func GenerateRucksacks(w io.Writer, rng *rand.Rand, numGroups int) error {
+	if numGroups < 1 {
+		return fmt.Errorf("number of groups must be at least 1 (got %d)", numGroups)
+	}
+	const groupSize = 3
+	items := []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	bw := bufio.NewWriter(w)
+	for i := 0; i < numGroups; i++ {
+		rng.Shuffle(len(items), func(a, b int) { items[a], items[b] = items[b], items[a] })
+		badge, rest := items[0], items[1:]
+		// Giving each elf its own pool of item types besides the badge
+		// guarantees that the badge is the only type shared by the group.
+		poolSize := len(rest) / groupSize
+		for e := 0; e < groupSize; e++ {
+			pool := append([]rune{badge}, rest[e*poolSize:(e+1)*poolSize]...)
+			bw.WriteString(rucksack(rng, pool))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// rucksack returns a random rucksack that contains the first item type in
+// pool and whose compartments share exactly one item type from pool.
+func rucksack(rng *rand.Rand, pool []rune) string {
+	must := pool[0]
+	types := append([]rune(nil), pool...)
+	rng.Shuffle(len(types), func(a, b int) { types[a], types[b] = types[b], types[a] })
+	shared, rest := types[0], types[1:]
+	split := 1 + rng.Intn(len(rest)-1)
+	left, right := rest[:split], rest[split:]
+	size := 2 + rng.Intn(15)
+	first := compartment(rng, size, shared, left)
+	second := compartment(rng, size, shared, right)
+	if must != shared {
+		// Put the required item type in whichever compartment it was
+		// assigned to, replacing a position that is not the shared item.
+		c := first
+		for _, t := range right {
+			if t == must {
+				c = second
+			}
+		}
+		c[size-1] = must
+	}
+	return string(first) + string(second)
+}
+
+// compartment returns size random items drawn from types with one of them
+// replaced by the shared item type. The shared item is never placed in the
+// last position.
+func compartment(rng *rand.Rand, size int, shared rune, types []rune) []rune {
+	items := make([]rune, size)
+	for i := range items {
+		items[i] = types[rng.Intn(len(types))]
+	}
+	items[rng.Intn(size-1)] = shared
+	return items
+}
@@ -0,0 +1,67 @@
+package elf_test
+
+import (
+	"bufio"
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/elf"
+)
+
+func TestGenerateRucksacksHasOneDuplicatePerRucksackAndOneBadgePerGroup(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	err := elf.GenerateRucksacks(&buf, rand.New(rand.NewSource(1)), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var group [][]rune
+	scn := bufio.NewScanner(&buf)
+	for scn.Scan() {
+		line := scn.Text()
+		if len(line)%2 != 0 {
+			t.Fatalf("want a rucksack with an even number of items, got %q", line)
+		}
+		dups := elf.FindDuplicateRucksackItems(line)
+		if len(dups) != 1 {
+			t.Fatalf("want 1 item type in both compartments of %q, got %d", line, len(dups))
+		}
+		group = append(group, []rune(line))
+		if len(group) < 3 {
+			continue
+		}
+		shared := map[rune]int{}
+		for _, rucksack := range group {
+			seen := map[rune]bool{}
+			for _, item := range rucksack {
+				if !seen[item] {
+					seen[item] = true
+					shared[item]++
+				}
+			}
+		}
+		numBadges := 0
+		for _, n := range shared {
+			if n == len(group) {
+				numBadges++
+			}
+		}
+		if numBadges != 1 {
+			t.Fatalf("want 1 item type shared by the group %q, got %d", group, numBadges)
+		}
+		group = nil
+	}
+	if err := scn.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateCaloriesWithInvalidSizeReturnsError(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	err := elf.GenerateCalories(&buf, rand.New(rand.NewSource(1)), 0)
+	if err == nil {
+		t.Error("expected an error but did not get one")
+	}
+}
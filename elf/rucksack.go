@@ -1,56 +1,62 @@
 package elf
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
 )
 
+// FindDuplicateRucksackItems accepts a rucksack and returns the item types
+// found in both of its compartments in ascending order. Nil is returned if no
+// item type is found in both compartments.
 func FindDuplicateRucksackItems(rucksack string) []rune {
 	if len(rucksack) < 2 {
 		return nil
 	}
 
 	ruckSackItems := []rune(rucksack)
-	firstCompartment := ruckSackItems[:len(ruckSackItems)/2]
-	secondCompartment := ruckSackItems[len(ruckSackItems)/2:]
-	itemSet := map[rune]struct{}{}
-	for _, v := range secondCompartment {
-		itemSet[v] = struct{}{}
-	}
-
-	dups := map[rune]struct{}{}
-	for _, v := range firstCompartment {
-		if _, ok := itemSet[v]; ok {
-			dups[v] = struct{}{}
-		}
-	}
-	var duplicates []rune
-	for k := range dups {
-		duplicates = append(duplicates, k)
-	}
-
-	return duplicates
+	firstCompartment := ds.NewSet(ruckSackItems[:len(ruckSackItems)/2]...)
+	secondCompartment := ds.NewSet(ruckSackItems[len(ruckSackItems)/2:]...)
+	return ds.SortedItems(firstCompartment.Intersect(secondCompartment))
 }
 
-func SumDuplicateRucksackItemPriorities(data io.Reader) (int, error) {
+// SumDuplicateRucksackItemPriorities accepts an io.Reader pointing to one
+// rucksack per line and returns the sum of the priorities of the items found in
+// both compartments of each rucksack. A rucksack holding an item without a
+// priority is reported as a *textio.ParseError.
+func SumDuplicateRucksackItemPriorities(data io.Reader, opts ...textio.Option) (int, error) {
 	if data == nil {
 		return 0, errors.New("data argument must be non-nil")
 	}
 
-	scn := bufio.NewScanner(data)
+	scn := textio.NewScanner(data, opts...)
 	priorities := rucksackItemPriorities()
 	sum := 0
 	for scn.Scan() {
-		sharedItems := FindDuplicateRucksackItems(scn.Text())
+		line := scn.Text()
+		sharedItems := FindDuplicateRucksackItems(line)
+		lineSum := 0
+		var err error
 		for _, v := range sharedItems {
 			priorityVal, ok := priorities[v]
 			if !ok {
-				return 0, fmt.Errorf("shared item %s must have an assigned priority value", string(v))
+				err = fmt.Errorf("shared item %s must have an assigned priority value", string(v))
+				err = textio.FieldError(strings.IndexRune(line, v)+1, string(v), err)
+				break
+			}
+			lineSum += priorityVal
+		}
+		if err != nil {
+			if err := scn.Skip(err); err != nil {
+				return 0, err
 			}
-			sum += priorityVal
+			continue
 		}
+		sum += lineSum
 	}
 	err := scn.Err()
 	if err != nil {
@@ -65,35 +71,22 @@ func FindBadgeInGroup(group [][]rune) (rune, error) {
 		return '0', fmt.Errorf("group size must be at least 2 (got %d)", len(group))
 	}
 
-	type set map[rune]struct{}
-	var sets []set
-	for _, grp := range group {
-		itemSet := set{}
-		for _, item := range grp {
-			itemSet[item] = struct{}{}
-		}
-		sets = append(sets, itemSet)
+	common := ds.NewSet(group[0]...)
+	for _, grp := range group[1:] {
+		common = common.Intersect(ds.NewSet(grp...))
 	}
-
-	firstSet := sets[0]
-	isBadgeItem := false
-	for item := range firstSet {
-		for _, nextSet := range sets[1:] {
-			if _, ok := nextSet[item]; !ok {
-				isBadgeItem = false
-				break
-			}
-			isBadgeItem = true
-		}
-		if isBadgeItem {
-			return item, nil
-		}
+	if badges := ds.SortedItems(common); len(badges) > 0 {
+		return badges[0], nil
 	}
 
 	return '0', errors.New("unable to locate a badge item type in the given group")
 }
 
-func SumBadgeItemPriorities(data io.Reader) (int, error) {
+// SumBadgeItemPriorities accepts an io.Reader pointing to one rucksack per line
+// and returns the sum of the priorities of the badge items shared by each group
+// of 3 rucksacks. A group without a valid badge is reported as a
+// *textio.ParseError positioned at its last rucksack.
+func SumBadgeItemPriorities(data io.Reader, opts ...textio.Option) (int, error) {
 	if data == nil {
 		return 0, errors.New("data argument must be non-nil")
 	}
@@ -103,7 +96,7 @@ func SumBadgeItemPriorities(data io.Reader) (int, error) {
 		group        [][]rune
 		numLinesRead int
 		sum          int
-		scn          = bufio.NewScanner(data)
+		scn          = textio.NewScanner(data, opts...)
 		priorities   = rucksackItemPriorities()
 	)
 	for scn.Scan() {
@@ -112,15 +105,19 @@ func SumBadgeItemPriorities(data io.Reader) (int, error) {
 		numLinesRead++
 		if numLinesRead%groupSize == 0 {
 			badge, err := FindBadgeInGroup(group)
-			if err != nil {
-				return 0, err
+			group = [][]rune{}
+			if err == nil {
+				if _, ok := priorities[badge]; !ok {
+					err = fmt.Errorf("badge item %s must have an assigned priority value", string(badge))
+				}
 			}
-			badgeVal, ok := priorities[badge]
-			if !ok {
-				return 0, fmt.Errorf("badge item %s must have an assigned priority value", string(badge))
+			if err != nil {
+				if err := scn.Skip(err); err != nil {
+					return 0, err
+				}
+				continue
 			}
-			sum += badgeVal
-			group = [][]rune{}
+			sum += priorities[badge]
 		}
 	}
 	err := scn.Err()
@@ -26,6 +26,10 @@ func TestFindDuplicateRucksackItems(t *testing.T) {
 			input: "AB",
 			want:  nil,
 		},
+		"Input with several duplicates returns them in ascending order": {
+			input: "cbaBXacbBY",
+			want:  []rune{'B', 'a', 'b', 'c'},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -0,0 +1,68 @@
+package elf
+
+import (
+	"io"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func init() {
+	solver.Register(solver.New(1, 1, solveDay1Part1))
+	solver.Register(solver.New(1, 2, solveDay1Part2))
+	solver.Register(solver.New(3, 1, solveDay3Part1))
+	solver.Register(solver.New(3, 2, solveDay3Part2))
+	solver.RegisterGenerator(1, GenerateCalories)
+	solver.RegisterGenerator(3, GenerateRucksacks)
+}
+
+func solveDay1Part1(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	topCounts, err := TopCaloryCounts(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(topCounts[0]), nil
+}
+
+func solveDay1Part2(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	topCounts, err := TopCaloryCounts(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	sum := 0
+	for _, v := range topCounts {
+		sum += v
+	}
+	return solver.IntAnswer(sum), nil
+}
+
+func solveDay3Part1(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	sum, err := SumDuplicateRucksackItemPriorities(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(sum), nil
+}
+
+func solveDay3Part2(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	sum, err := SumBadgeItemPriorities(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(sum), nil
+}
@@ -0,0 +1,259 @@
+// Package inputs provides access to a local store of puzzle inputs. The store
+// is a directory containing one subdirectory per puzzle year, and each day may
+// have several named inputs:
+//
+//	inputs/2022/day07.txt          the real puzzle input (named "real")
+//	inputs/2022/day07.example.txt  the example from the puzzle description
+//	inputs/2022/day07.stress.txt   a large generated input
+//
+// Recorded answers for an input live next to it with an .answers extension and
+// SHA-256 checksums of the inputs are recorded in a SHA256SUMS file in the
+// year directory, in the format produced by the sha256sum tool.
+package inputs
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Year is the puzzle year whose inputs are held in the store.
+const Year = 2022
+
+// EnvDir is the name of the environment variable that configures the root
+// directory of the default store.
+const EnvDir = "AOC_INPUT_DIR"
+
+// DefaultDir is the root directory of the default store when EnvDir is unset.
+const DefaultDir = "inputs"
+
+// SumsFile is the name of the file in the year directory that holds the
+// recorded checksums of the inputs.
+const SumsFile = "SHA256SUMS"
+
+// Common input names.
+const (
+	Real    = "real"
+	Example = "example"
+	Stress  = "stress"
+)
+
+// ErrChecksumMismatch is returned when an input does not match its recorded
+// checksum.
+var ErrChecksumMismatch = errors.New("input does not match its recorded checksum")
+
+// inputFileRgx defines what the file name of an input in the store looks like.
+var inputFileRgx = regexp.MustCompile(`^day(\d\d)(?:\.([a-z0-9_-]+))?\.txt$`)
+
+// nameRgx defines what a valid input name looks like.
+var nameRgx = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// Store represents a local directory of puzzle inputs.
+type Store struct {
+	Dir string
+}
+
+// NewStore accepts the root directory of a store and returns a Store.
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// DefaultStore returns a Store rooted at the directory named by the
+// AOC_INPUT_DIR environment variable, or at DefaultDir if it is unset.
+func DefaultStore() *Store {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		return NewStore(dir)
+	}
+	return NewStore(DefaultDir)
+}
+
+// YearDir returns the directory holding the inputs for Year.
+func (s *Store) YearDir() string {
+	return filepath.Join(s.Dir, strconv.Itoa(Year))
+}
+
+// Path returns the location of the named input for the given day. An empty
+// name refers to the real input.
+func (s *Store) Path(day int, name string) string {
+	return filepath.Join(s.YearDir(), fileName(day, name, ".txt"))
+}
+
+// AnswersPath returns the location of the recorded answers for the named input
+// for the given day.
+func (s *Store) AnswersPath(day int, name string) string {
+	return filepath.Join(s.YearDir(), fileName(day, name, ".answers"))
+}
+
+// fileName returns the base name of a file belonging to the named input for
+// the given day.
+func fileName(day int, name, ext string) string {
+	if name == "" || name == Real {
+		return fmt.Sprintf("day%02d%s", day, ext)
+	}
+	return fmt.Sprintf("day%02d.%s%s", day, name, ext)
+}
+
+// Input represents the contents of a puzzle input loaded from a store.
+type Input struct {
+	Day  int
+	Name string
+	Path string
+	Data []byte
+	// SHA256 is the hex-encoded SHA-256 checksum of Data.
+	SHA256 string
+	// Verified is true when the input matched a recorded checksum.
+	Verified bool
+}
+
+// Reader returns a new io.Reader positioned at the start of the input. Each
+// call returns an independent reader, so every part of a puzzle can be given
+// its own reader.
+func (in *Input) Reader() io.Reader {
+	return bytes.NewReader(in.Data)
+}
+
+// Load reads the named input for the given day and validates it against its
+// recorded checksum, if one exists. An error wrapping ErrChecksumMismatch is
+// returned if the input does not match its recorded checksum, and an error
+// wrapping fs.ErrNotExist is returned if the input does not exist.
+func (s *Store) Load(day int, name string) (*Input, error) {
+	if err := validate(day, name); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = Real
+	}
+	path := s.Path(day, name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	in := &Input{Day: day, Name: name, Path: path, Data: data, SHA256: checksum(data)}
+	sums, err := s.Sums()
+	if err != nil {
+		return nil, err
+	}
+	want, ok := sums[filepath.Base(path)]
+	if !ok {
+		return in, nil
+	}
+	if want != in.SHA256 {
+		return nil, fmt.Errorf("%s: %w (want %s, got %s)", path, ErrChecksumMismatch, want, in.SHA256)
+	}
+	in.Verified = true
+	return in, nil
+}
+
+// Names returns the names of the inputs that exist for the given day in
+// ascending order.
+func (s *Store) Names(day int) ([]string, error) {
+	entries, err := os.ReadDir(s.YearDir())
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		submatches := inputFileRgx.FindStringSubmatch(e.Name())
+		if e.IsDir() || submatches == nil {
+			continue
+		}
+		if d, _ := strconv.Atoi(submatches[1]); d != day {
+			continue
+		}
+		name := submatches[2]
+		if name == "" {
+			name = Real
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Sums returns the recorded checksums keyed by input file name. An empty map
+// is returned if no checksums have been recorded.
+func (s *Store) Sums() (map[string]string, error) {
+	sums := make(map[string]string)
+	f, err := os.Open(filepath.Join(s.YearDir(), SumsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return sums, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scn := bufio.NewScanner(f)
+	lineNum := 0
+	for scn.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scn.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s line %d must be in the form <sha256> <file> (got %s)", SumsFile, lineNum, line)
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+	if err := scn.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// Record computes the checksum of the named input for the given day and
+// records it in the store's SHA256SUMS file, replacing any previously recorded
+// checksum for the input.
+func (s *Store) Record(day int, name string) error {
+	if err := validate(day, name); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(s.Path(day, name))
+	if err != nil {
+		return err
+	}
+	sums, err := s.Sums()
+	if err != nil {
+		return err
+	}
+	sums[fileName(day, name, ".txt")] = checksum(data)
+	var files []string
+	for f := range sums {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	var buf bytes.Buffer
+	for _, f := range files {
+		fmt.Fprintf(&buf, "%s  %s\n", sums[f], f)
+	}
+	return os.WriteFile(filepath.Join(s.YearDir(), SumsFile), buf.Bytes(), 0o644)
+}
+
+// validate returns an error if the day or input name is invalid.
+func validate(day int, name string) error {
+	if day < 1 || day > 25 {
+		return fmt.Errorf("day must be between 1 and 25 inclusive (got %d)", day)
+	}
+	if name != "" && !nameRgx.MatchString(name) {
+		return fmt.Errorf("input name must contain only lowercase letters, digits, dashes and underscores (got %q)", name)
+	}
+	return nil
+}
+
+// checksum returns the hex-encoded SHA-256 checksum of data.
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
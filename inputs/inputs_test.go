@@ -0,0 +1,159 @@
+package inputs_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestStore returns a Store in a temporary directory containing the given
+// files in its year directory.
+func newTestStore(t *testing.T, files map[string]string) *inputs.Store {
+	t.Helper()
+	store := inputs.NewStore(t.TempDir())
+	if err := os.MkdirAll(store.YearDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(store.YearDir(), name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func TestStore_Path(t *testing.T) {
+	t.Parallel()
+	store := inputs.NewStore("root")
+	testCases := map[string]struct {
+		day  int
+		name string
+		want string
+	}{
+		"Real input has no name in its file name": {
+			day:  7,
+			name: inputs.Real,
+			want: filepath.Join("root", "2022", "day07.txt"),
+		},
+		"Empty name refers to the real input": {
+			day:  7,
+			want: filepath.Join("root", "2022", "day07.txt"),
+		},
+		"Named input includes its name in its file name": {
+			day:  11,
+			name: inputs.Example,
+			want: filepath.Join("root", "2022", "day11.example.txt"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := store.Path(tc.day, tc.name)
+			if tc.want != got {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDefaultStoreUsesEnvironmentVariable(t *testing.T) {
+	t.Setenv(inputs.EnvDir, "/tmp/aoc-inputs")
+	want := "/tmp/aoc-inputs"
+	got := inputs.DefaultStore().Dir
+	if want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestStore_LoadReturnsFreshReaderPerCall(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[string]string{"day01.txt": "1000\n2000\n"})
+	in, err := store.Load(1, inputs.Real)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		got, err := io.ReadAll(in.Reader())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "1000\n2000\n" {
+			t.Errorf("read %d: want full input, got %q", i, got)
+		}
+	}
+	if in.Verified {
+		t.Error("want input without a recorded checksum to be unverified")
+	}
+}
+
+func TestStore_LoadValidatesRecordedChecksum(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[string]string{"day02.example.txt": "A Y\n"})
+	if err := store.Record(2, inputs.Example); err != nil {
+		t.Fatal(err)
+	}
+	in, err := store.Load(2, inputs.Example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !in.Verified {
+		t.Error("want input matching its recorded checksum to be verified")
+	}
+	err = os.WriteFile(store.Path(2, inputs.Example), []byte("B X\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Load(2, inputs.Example)
+	if !errors.Is(err, inputs.ErrChecksumMismatch) {
+		t.Errorf("want error %v, got %v", inputs.ErrChecksumMismatch, err)
+	}
+}
+
+func TestStore_LoadErrorCases(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, nil)
+	testCases := map[string]struct {
+		day  int
+		name string
+	}{
+		"Missing input returns error": {day: 3, name: inputs.Real},
+		"Invalid day returns error":   {day: 0, name: inputs.Real},
+		"Invalid name returns error":  {day: 3, name: "../day04"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := store.Load(tc.day, tc.name)
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+	_, err := store.Load(3, inputs.Real)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("want missing input error to wrap %v, got %v", fs.ErrNotExist, err)
+	}
+}
+
+func TestStore_Names(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t, map[string]string{
+		"day07.txt":         "",
+		"day07.example.txt": "",
+		"day07.stress.txt":  "",
+		"day07.answers":     "",
+		"day08.txt":         "",
+	})
+	want := []string{inputs.Example, inputs.Real, inputs.Stress}
+	got, err := store.Names(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,69 @@
+package mitm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// GenerateMonkeys writes random notes for a game of eight monkeys holding a
+// total of numItems items to w. Like the real puzzle input, every monkey tests
+// for divisibility by a different prime and exactly one monkey squares the
+// worry level. An error is returned if numItems is smaller than the number of
+// monkeys (every monkey starts with at least one item) or if there is a
+// problem writing to w.
+func GenerateMonkeys(w io.Writer, rng *rand.Rand, numItems int) error {
+	primes := []int{2, 3, 5, 7, 11, 13, 17, 19}
+	numMonkeys := len(primes)
+	if numItems < numMonkeys {
+		return fmt.Errorf("number of items must be at least %d (got %d)", numMonkeys, numItems)
+	}
+	items := make([][]string, numMonkeys)
+	for i := 0; i < numItems; i++ {
+		m := i
+		if i >= numMonkeys {
+			m = rng.Intn(numMonkeys)
+		}
+		items[m] = append(items[m], strconv.Itoa(50+rng.Intn(50)))
+	}
+	rng.Shuffle(numMonkeys, func(a, b int) { primes[a], primes[b] = primes[b], primes[a] })
+	squarer := rng.Intn(numMonkeys)
+	bw := bufio.NewWriter(w)
+	for id := 0; id < numMonkeys; id++ {
+		if id > 0 {
+			bw.WriteByte('\n')
+		}
+		operation := fmt.Sprintf("old + %d", 1+rng.Intn(8))
+		switch {
+		case id == squarer:
+			operation = "old * old"
+		case rng.Intn(4) == 0:
+			operation = fmt.Sprintf("old * %d", 2+rng.Intn(18))
+		}
+		destIfTrue := otherMonkey(rng, id, numMonkeys)
+		destIfFalse := otherMonkey(rng, id, numMonkeys)
+		for destIfFalse == destIfTrue {
+			destIfFalse = otherMonkey(rng, id, numMonkeys)
+		}
+		fmt.Fprintf(bw, "Monkey %d:\n", id)
+		fmt.Fprintf(bw, "  Starting items: %s\n", strings.Join(items[id], ", "))
+		fmt.Fprintf(bw, "  Operation: new = %s\n", operation)
+		fmt.Fprintf(bw, "  Test: divisible by %d\n", primes[id])
+		fmt.Fprintf(bw, "    If true: throw to monkey %d\n", destIfTrue)
+		fmt.Fprintf(bw, "    If false: throw to monkey %d\n", destIfFalse)
+	}
+	return bw.Flush()
+}
+
+// otherMonkey returns the id of a random monkey other than the monkey with the
+// given id.
+func otherMonkey(rng *rand.Rand, id, numMonkeys int) int {
+	other := rng.Intn(numMonkeys - 1)
+	if other >= id {
+		other++
+	}
+	return other
+}
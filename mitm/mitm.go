@@ -1,15 +1,15 @@
 package mitm
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 var worryFuncs = map[string]func(int, int) int{
@@ -56,33 +56,26 @@ func (a AdjustWorryLevelPart2) Adjust(oldWorryLevel int) int {
 // MonkeysFromInput accepts an io.Reader pointing to line-separated Monkey
 // attribute data and returns a slice of Monkey structs built from those
 // attributes. An error is returned if the input cannot be processed or if there
-// is a problem constructing a Monkey struct from the given attributes.
-func MonkeysFromInput(input io.Reader) ([]*Monkey, error) {
+// is a problem constructing a Monkey struct from the given attributes. Invalid
+// attributes are reported as a *textio.ParseError. The options control how the
+// input is normalized; since a monkey cannot be built without all of its
+// attributes, invalid attributes fail the parse even with textio.Lenient.
+func MonkeysFromInput(input io.Reader, opts ...textio.Option) ([]*Monkey, error) {
 	if input == nil {
 		return nil, errors.New("input must be non-nil")
 	}
-	var (
-		monkeys []*Monkey
-		next    *Monkey
-	)
-	scn := bufio.NewScanner(input)
-	for scn.Scan() {
-		line := strings.TrimSpace(scn.Text())
-		if !strings.HasPrefix(line, "Monkey") {
-			continue
-		}
-		next, err := parseMonkey(line, scn)
+	var monkeys []*Monkey
+	blocks := textio.NewBlockScanner(input, opts...)
+	for blocks.Scan() {
+		monkey, err := parseMonkey(blocks)
 		if err != nil {
 			return nil, err
 		}
-		monkeys = append(monkeys, next)
+		monkeys = append(monkeys, monkey)
 	}
-	if err := scn.Err(); err != nil {
+	if err := blocks.Err(); err != nil {
 		return nil, err
 	}
-	if next != nil {
-		monkeys = append(monkeys, next)
-	}
 	return monkeys, nil
 }
 
@@ -94,11 +87,44 @@ type WorryLevelAdjuster interface {
 	Adjust(int) int
 }
 
+// RoundEvent describes the state of the game after a round has been played.
+type RoundEvent struct {
+	// Round is the number of the round that was played, starting at 1.
+	Round     int
+	NumRounds int
+	Monkeys   []*Monkey
+}
+
+// RunOpt represents a functional option that can be passed in during a call
+// to the Run() function.
+type RunOpt func(*runConfig)
+
+// runConfig holds the settings applied by the RunOpts given to Run.
+type runConfig struct {
+	observe func(RoundEvent)
+}
+
+// WithObserver accepts a function and returns a RunOpt that makes Run call it
+// with a RoundEvent after every round. The observer must not modify the
+// monkeys.
+func WithObserver(fn func(RoundEvent)) RunOpt {
+	return func(c *runConfig) {
+		c.observe = fn
+	}
+}
+
 // Run accepts a slice of Monkey structs and an number of rounds to execute and
 // runs the monkey-in-the-middle game for the specified number of rounds. An
 // error is returned if invalid arguments are provided to the function or if a
 // monkey attempts to throw one of it's items to an invalid destination monkey.
-func Run(monkeys []*Monkey, numRounds int, adjuster WorryLevelAdjuster) error {
+func Run(monkeys []*Monkey, numRounds int, adjuster WorryLevelAdjuster, opts ...RunOpt) error {
+	return RunContext(context.Background(), monkeys, numRounds, adjuster, opts...)
+}
+
+// RunContext behaves like Run but checks ctx before each round and returns
+// ctx.Err() if ctx has been cancelled. The monkeys are left in the state
+// reached by the completed rounds.
+func RunContext(ctx context.Context, monkeys []*Monkey, numRounds int, adjuster WorryLevelAdjuster, opts ...RunOpt) error {
 	if monkeys == nil {
 		return errors.New("monkeys argument must be non-nil")
 	}
@@ -108,7 +134,14 @@ func Run(monkeys []*Monkey, numRounds int, adjuster WorryLevelAdjuster) error {
 	if adjuster == nil {
 		return errors.New("must provide a non-nil worry level adjuster argument")
 	}
+	var cfg runConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
 	for i := 0; i < numRounds; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		for _, mk := range monkeys {
 			for mk.Items.Size() > 0 {
 				worryLevel, _ := mk.Items.Dequeue()
@@ -125,6 +158,9 @@ func Run(monkeys []*Monkey, numRounds int, adjuster WorryLevelAdjuster) error {
 				monkeys[destMonkey].Items.Enqueue(worryLevel)
 			}
 		}
+		if cfg.observe != nil {
+			cfg.observe(RoundEvent{Round: i + 1, NumRounds: numRounds, Monkeys: monkeys})
+		}
 	}
 	return nil
 }
@@ -140,12 +176,12 @@ func MonkeyBusiness(monkeys []*Monkey) int {
 	if len(monkeys) == 1 {
 		return monkeys[0].NumItemsInspected
 	}
-	var copy []*Monkey
-	copy = append(copy, monkeys...)
-	sort.Slice(copy, func(i, j int) bool {
-		return copy[i].NumItemsInspected < copy[j].NumItemsInspected
-	})
-	return copy[len(copy)-1].NumItemsInspected * copy[len(copy)-2].NumItemsInspected
+	busiest := ds.NewPriorityQueueFromItems(func(a, b *Monkey) bool {
+		return a.NumItemsInspected > b.NumItemsInspected
+	}, monkeys...)
+	first, _ := busiest.Pop()
+	second, _ := busiest.Pop()
+	return first.NumItemsInspected * second.NumItemsInspected
 }
 
 // CommonMultiple accepts a slice of Monkey structs and returns the common
@@ -162,104 +198,108 @@ func CommonMultiple(monkeys []*Monkey) int {
 	return multiple
 }
 
-// parseMonkey accepts a monkey indicator line (e.g. "Monkey 0") and a Scanner
-// that reads line-separated monkey attributes for the indicated monkey and
-// returns a Monkey struct. An error is returned if there is problem scanning
-// the input or if an invalid attribute is encountered.
-func parseMonkey(monkeyIDLine string, scn *bufio.Scanner) (*Monkey, error) {
-	flds := strings.Fields(monkeyIDLine)
-	if len(flds) < 2 {
-		return nil, fmt.Errorf(`expected monkey id line to have an id value, got "%s"`, monkeyIDLine)
+// parseMonkey accepts a BlockScanner positioned at a block of monkey
+// attributes starting with the monkey indicator line (e.g. "Monkey 0:") and
+// returns a Monkey struct built from the attributes. An error is returned if an
+// invalid attribute is encountered, if an attribute is missing or if the
+// monkey throws to itself. Errors in an attribute line are returned as a
+// *textio.ParseError giving the position of the offending field.
+func parseMonkey(blocks *textio.BlockScanner) (*Monkey, error) {
+	block := blocks.Block()
+	flds := textio.Fields(block.Lines[0])
+	if len(flds) < 2 || flds[0].Text != "Monkey" {
+		return nil, blocks.Error(0, fmt.Errorf(`expected monkey id line in the form "Monkey <id>:", got "%s"`, block.Lines[0]))
 	}
-	id, err := strconv.Atoi(strings.TrimSuffix(flds[1], ":"))
+	id, err := strconv.Atoi(strings.TrimSuffix(flds[1].Text, ":"))
 	if err != nil {
-		return nil, err
+		return nil, blocks.Error(0, textio.FieldError(flds[1].Column, flds[1].Text, err))
 	}
 	monkey := &Monkey{ID: id}
-	for scn.Scan() {
-		line := strings.TrimSpace(scn.Text())
+	seen := make(map[string]bool)
+	for i := 1; i < len(block.Lines); i++ {
+		line := strings.TrimSpace(block.Lines[i])
+		flds = textio.Fields(block.Lines[i])
 		switch {
 		case strings.HasPrefix(line, "Starting items: "):
 			monkeyItems := ds.NewQueue[int]()
-			res := strings.Split(line, "Starting items: ")
-			if len(res) < 2 {
-				return nil, fmt.Errorf("line must contain worry levels, got %s", line)
+			if len(flds) < 3 {
+				return nil, blocks.Error(i, fmt.Errorf("line must contain worry levels, got %s", line))
 			}
-			items := strings.Split(res[1], ",")
-			if len(items) < 1 {
-				return nil, fmt.Errorf("line must contain worry levels, got %s", line)
-			}
-			for _, item := range items {
-				val, err := strconv.Atoi(strings.TrimSpace(item))
+			for _, item := range flds[2:] {
+				val, err := strconv.Atoi(strings.TrimSuffix(item.Text, ","))
 				if err != nil {
-					return nil, err
+					return nil, blocks.Error(i, textio.FieldError(item.Column, item.Text, err))
 				}
 				monkeyItems.Enqueue(val)
 			}
 			monkey.Items = monkeyItems
+			seen["Starting items"] = true
 		case strings.HasPrefix(line, "Operation:"):
-			flds = strings.Fields(line)
 			if len(flds) < 6 {
-				return nil, fmt.Errorf("line must contain a valid worry calculation, got %s", line)
+				return nil, blocks.Error(i, fmt.Errorf("line must contain a valid worry calculation, got %s", line))
 			}
 			op := flds[4]
-			worryCalc, ok := worryFuncs[op]
+			worryCalc, ok := worryFuncs[op.Text]
 			if !ok {
-				return nil, fmt.Errorf("no worry calculation function defined for operator %s", op)
+				return nil, blocks.Error(i, textio.FieldError(op.Column, op.Text, fmt.Errorf("no worry calculation function defined for operator %s", op.Text)))
 			}
+			seen["Operation"] = true
 			opnd := flds[5]
-			if opnd == "old" {
+			if opnd.Text == "old" {
 				monkey.WorryCalc = func(old int) int {
 					return worryCalc(old, old)
 				}
 				continue
 			}
-			opndVal, err := strconv.Atoi(opnd)
+			opndVal, err := strconv.Atoi(opnd.Text)
 			if err != nil {
-				return nil, err
+				return nil, blocks.Error(i, textio.FieldError(opnd.Column, opnd.Text, err))
 			}
 			monkey.WorryCalc = func(old int) int {
 				return worryCalc(old, opndVal)
 			}
 		case strings.HasPrefix(line, "Test:"):
-			flds = strings.Fields(line)
 			if len(flds) < 4 {
-				return nil, fmt.Errorf("line must contain a valid test condition, got %s", line)
+				return nil, blocks.Error(i, fmt.Errorf("line must contain a valid test condition, got %s", line))
 			}
-			div, err := strconv.Atoi(flds[3])
+			div, err := strconv.Atoi(flds[3].Text)
 			if err != nil {
-				return nil, err
+				return nil, blocks.Error(i, textio.FieldError(flds[3].Column, flds[3].Text, err))
 			}
 			if div == 0 {
-				return nil, errors.New("test condition line must contain a non-zero divisor")
+				return nil, blocks.Error(i, textio.FieldError(flds[3].Column, flds[3].Text, errors.New("test condition line must contain a non-zero divisor")))
 			}
 			monkey.TestDivisor = div
+			seen["Test"] = true
 		case strings.HasPrefix(line, "If true:") || strings.HasPrefix(line, "If false:"):
-			flds = strings.Fields(line)
 			if len(flds) < 6 {
-				return nil, fmt.Errorf("line must contain a valid test result definition, got %s", line)
+				return nil, blocks.Error(i, fmt.Errorf("line must contain a valid test result definition, got %s", line))
 			}
-			dest, err := strconv.Atoi(flds[5])
+			dest, err := strconv.Atoi(flds[5].Text)
 			if err != nil {
-				return nil, err
+				return nil, blocks.Error(i, textio.FieldError(flds[5].Column, flds[5].Text, err))
 			}
 			if dest < 0 {
-				return nil, fmt.Errorf("destination must be a non-negative int, got %d", dest)
+				return nil, blocks.Error(i, textio.FieldError(flds[5].Column, flds[5].Text, fmt.Errorf("destination must be a non-negative int, got %d", dest)))
+			}
+			if dest == id {
+				return nil, blocks.Error(i, textio.FieldError(flds[5].Column, flds[5].Text, fmt.Errorf("monkey %d must not throw to itself", id)))
 			}
-			if flds[1] == "true:" {
+			if flds[1].Text == "true:" {
 				monkey.DestIfTrue = dest
+				seen["If true"] = true
 			} else {
 				monkey.DestIfFalse = dest
+				seen["If false"] = true
 			}
-		case line == "":
-			return monkey, nil
 		default:
-			return nil, fmt.Errorf("no processing logic exists for line %s", line)
+			return nil, blocks.Error(i, fmt.Errorf("no processing logic exists for line %s", line))
 		}
 	}
-	err = scn.Err()
-	if err != nil {
-		return nil, err
+	for _, attr := range []string{"Starting items", "Operation", "Test", "If true", "If false"} {
+		if !seen[attr] {
+			return nil, blocks.Error(0, fmt.Errorf("monkey %d is missing the %q attribute", id, attr))
+		}
 	}
 	return monkey, nil
 }
@@ -1,11 +1,14 @@
 package mitm_test
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/aculclasure/aoc2022/ds"
 	"github.com/aculclasure/aoc2022/mitm"
+	"github.com/aculclasure/aoc2022/textio"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -260,6 +263,52 @@ func TestMonkeysFromInputErrorCases(t *testing.T) {
 				If true: throw to monkey 2
 			  	If false: throw to monkey -3
 			`,
+		"Monkey with only an ID line returns error": `Monkey 0:
+			`,
+		"Monkey with no starting items line returns error": `Monkey 0:
+			Operation: new = old * 19
+			Test: divisible by 23
+				If true: throw to monkey 2
+			  	If false: throw to monkey 3
+			`,
+		"Monkey with no operation line returns error": `Monkey 0:
+			Starting items: 79, 98
+			Test: divisible by 23
+				If true: throw to monkey 2
+			  	If false: throw to monkey 3
+			`,
+		"Monkey with no test line returns error": `Monkey 0:
+			Starting items: 79, 98
+			Operation: new = old * 19
+				If true: throw to monkey 2
+			  	If false: throw to monkey 3
+			`,
+		"Monkey with no true test result line returns error": `Monkey 0:
+			Starting items: 79, 98
+			Operation: new = old * 19
+			Test: divisible by 23
+			  	If false: throw to monkey 3
+			`,
+		"Monkey with no false test result line returns error": `Monkey 0:
+			Starting items: 79, 98
+			Operation: new = old * 19
+			Test: divisible by 23
+				If true: throw to monkey 2
+			`,
+		"True test result line throwing to the same monkey returns error": `Monkey 0:
+			Starting items: 79, 98
+			Operation: new = old * 19
+			Test: divisible by 23
+				If true: throw to monkey 0
+			  	If false: throw to monkey 3
+			`,
+		"False test result line throwing to the same monkey returns error": `Monkey 1:
+			Starting items: 79, 98
+			Operation: new = old * 19
+			Test: divisible by 23
+				If true: throw to monkey 2
+			  	If false: throw to monkey 1
+			`,
 	}
 	for name, input := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -445,3 +494,108 @@ func getTestMonkeys() []*mitm.Monkey {
 		},
 	}
 }
+
+func TestMonkeysFromInputReturnsParseErrorForInvalidAttribute(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader(`Monkey 0:
+  Starting items: 79, 98
+  Operation: new = old * 19
+  Test: divisible by 23
+    If true: throw to monkey 1
+    If false: throw to monkey 1
+
+Monkey 1:
+  Starting items: 54, 6x, 75
+  Operation: new = old + 6
+  Test: divisible by 19
+    If true: throw to monkey 0
+    If false: throw to monkey 0
+`)
+	_, err := mitm.MonkeysFromInput(input)
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError, got %v", err)
+	}
+	if pe.Line != 9 || pe.Column != 23 || pe.Text != "6x," {
+		t.Errorf("want line 9, column 23, text 6x, got line %d, column %d, text %s", pe.Line, pe.Column, pe.Text)
+	}
+}
+
+func TestMonkeysFromInputWithExtraBlankLinesAndNoTrailingNewline(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader(`
+
+Monkey 0:
+  Starting items: 79, 98
+  Operation: new = old * 19
+  Test: divisible by 23
+    If true: throw to monkey 1
+    If false: throw to monkey 1
+
+
+
+Monkey 1:
+  Starting items: 54
+  Operation: new = old + 6
+  Test: divisible by 19
+    If true: throw to monkey 0
+    If false: throw to monkey 0`)
+	monkeys, err := mitm.MonkeysFromInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int
+	for _, m := range monkeys {
+		got = append(got, m.ID)
+	}
+	want := []int{0, 1}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRunContextWithCancelledContextReturnsContextError(t *testing.T) {
+	t.Parallel()
+	monkeys := getTestMonkeys()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := mitm.RunContext(ctx, monkeys, 10000, mitm.AdjustWorryLevelPart1{Divisor: 3})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want error %v, got %v", context.Canceled, err)
+	}
+	for _, mk := range monkeys {
+		if mk.NumItemsInspected != 0 {
+			t.Errorf("want no items inspected by monkey %d, got %d", mk.ID, mk.NumItemsInspected)
+		}
+	}
+}
+
+func TestRunCallsObserverAfterEachRound(t *testing.T) {
+	t.Parallel()
+	monkeys := getTestMonkeys()
+	var gotRounds []int
+	var gotInspected []int
+	observe := mitm.WithObserver(func(e mitm.RoundEvent) {
+		if e.NumRounds != 3 {
+			t.Errorf("want 3 rounds, got %d", e.NumRounds)
+		}
+		gotRounds = append(gotRounds, e.Round)
+		gotInspected = append(gotInspected, e.Monkeys[0].NumItemsInspected)
+	})
+	err := mitm.Run(monkeys, 3, mitm.AdjustWorryLevelPart1{Divisor: 3}, observe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRounds := []int{1, 2, 3}
+	if !cmp.Equal(wantRounds, gotRounds) {
+		t.Error(cmp.Diff(wantRounds, gotRounds))
+	}
+	for i := 1; i < len(gotInspected); i++ {
+		if gotInspected[i] < gotInspected[i-1] {
+			t.Errorf("want inspected items to accumulate across rounds, got %v", gotInspected)
+		}
+	}
+	if last := gotInspected[len(gotInspected)-1]; last != monkeys[0].NumItemsInspected {
+		t.Errorf("want last event to report %d inspected items, got %d", monkeys[0].NumItemsInspected, last)
+	}
+}
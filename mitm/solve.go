@@ -0,0 +1,43 @@
+package mitm
+
+import (
+	"context"
+	"io"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func init() {
+	solver.Register(solver.NewPhasedContext(11, 1, readMonkeys, solveDay11Part1))
+	solver.Register(solver.NewPhasedContext(11, 2, readMonkeys, solveDay11Part2))
+	solver.RegisterGenerator(11, GenerateMonkeys)
+}
+
+// readMonkeys parses the monkeys of day 11 from the input. An error is
+// returned if the input is empty.
+func readMonkeys(input io.Reader) ([]*Monkey, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return nil, err
+	}
+	return MonkeysFromInput(input)
+}
+
+func solveDay11Part1(ctx context.Context, monkeys []*Monkey) (solver.Answer, error) {
+	return monkeyBusinessAfter(ctx, monkeys, 20, AdjustWorryLevelPart1{Divisor: 3})
+}
+
+func solveDay11Part2(ctx context.Context, monkeys []*Monkey) (solver.Answer, error) {
+	return monkeyBusinessAfter(ctx, monkeys, 10000, AdjustWorryLevelPart2{CommonMultiple: CommonMultiple(monkeys)})
+}
+
+// monkeyBusinessAfter runs the game for numRounds rounds and returns the
+// resulting level of monkey business as an Answer. It stops early with
+// ctx.Err() if ctx is cancelled.
+func monkeyBusinessAfter(ctx context.Context, monkeys []*Monkey, numRounds int, adjuster WorryLevelAdjuster) (solver.Answer, error) {
+	err := RunContext(ctx, monkeys, numRounds, adjuster)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(MonkeyBusiness(monkeys)), nil
+}
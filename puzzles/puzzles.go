@@ -0,0 +1,16 @@
+// Package puzzles imports every puzzle package in this module so that their
+// solvers are registered with the solver package. Import it for its side
+// effects:
+//
+//	import _ "github.com/aculclasure/aoc2022/puzzles"
+package puzzles
+
+import (
+	_ "github.com/aculclasure/aoc2022/camp"
+	_ "github.com/aculclasure/aoc2022/cargo"
+	_ "github.com/aculclasure/aoc2022/devices"
+	_ "github.com/aculclasure/aoc2022/elf"
+	_ "github.com/aculclasure/aoc2022/mitm"
+	_ "github.com/aculclasure/aoc2022/rope"
+	_ "github.com/aculclasure/aoc2022/rps"
+)
@@ -0,0 +1,27 @@
+package rope
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// GenerateMotions writes numMotions random head motions to w, one
+// "<direction> <steps>" motion per line with directions U, D, L or R and
+// between 1 and 20 steps. An error is returned if numMotions is smaller than
+// 1 or if there is a problem writing to w.
+func GenerateMotions(w io.Writer, rng *rand.Rand, numMotions int) error {
+	if numMotions < 1 {
+		return fmt.Errorf("number of motions must be at least 1 (got %d)", numMotions)
+	}
+	const (
+		directions = "UDLR"
+		maxSteps   = 20
+	)
+	bw := bufio.NewWriter(w)
+	for i := 0; i < numMotions; i++ {
+		fmt.Fprintf(bw, "%c %d\n", directions[rng.Intn(len(directions))], 1+rng.Intn(maxSteps))
+	}
+	return bw.Flush()
+}
@@ -3,12 +3,14 @@
 package rope
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"strconv"
-	"strings"
+
+	"github.com/aculclasure/aoc2022/ds"
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 // Opt represents a functional option that can be passed in during a call to the
@@ -30,6 +32,28 @@ func WithNumKnots(n int) Opt {
 	}
 }
 
+// WithObserver accepts a function and returns an Opt that configures a Rope to
+// call it with a StepEvent after every step of its head.
+func WithObserver(fn func(StepEvent)) Opt {
+	return func(r *Rope) error {
+		if fn == nil {
+			return errors.New("observer must be non-nil")
+		}
+		r.observe = fn
+		return nil
+	}
+}
+
+// StepEvent describes a single step of a rope's head by one row or column,
+// after the rest of the rope has followed it.
+type StepEvent struct {
+	// Step is the number of steps the head has taken so far, starting at 1.
+	Step int
+	// Delta is the offset by which the head moved.
+	Delta ds.Point
+	Rope  *Rope
+}
+
 // Rope represents a rope with a head knot, tail knot, and an arbitrary number
 // of knots between the head and tail knots.
 type Rope struct {
@@ -37,29 +61,39 @@ type Rope struct {
 	Tail     *RopeEnd
 	NumKnots int
 	Knots    []*RopeEnd
+	steps    int
+	observe  func(StepEvent)
 }
 
 // MoveHead accepts a number of row and number of columns and moves the rope's
-// head end accordingly. After each move of the head end, the tail end is also
-// moved if needed.
+// head end accordingly. It is a convenience wrapper around MoveHeadBy.
 func (r *Rope) MoveHead(numRows, numCols int) {
-	rowDir := 1
-	colDir := 1
-	if numRows < 0 {
-		numRows *= -1
-		rowDir = -1
-	}
-	if numCols < 0 {
-		numCols *= -1
-		colDir = -1
+	r.MoveHeadBy(ds.Point{Row: numRows, Col: numCols})
+}
+
+// MoveHeadBy accepts an offset and moves the rope's head end by it one step at
+// a time, first along the rows and then along the columns. After each move of
+// the head end, the tail end is also moved if needed. If the rope was created
+// with WithObserver, a StepEvent is passed to the observer after each step.
+func (r *Rope) MoveHeadBy(offset ds.Point) {
+	rowStep := ds.Point{Row: offset.Row}.Sign()
+	for i := 0; i < abs(offset.Row); i++ {
+		r.step(rowStep)
 	}
-	for i := 0; i < numRows; i++ {
-		r.Head.Move(rowDir*1, 0)
-		r.UpdateTail()
+	colStep := ds.Point{Col: offset.Col}.Sign()
+	for i := 0; i < abs(offset.Col); i++ {
+		r.step(colStep)
 	}
-	for i := 0; i < numCols; i++ {
-		r.Head.Move(0, colDir*1)
-		r.UpdateTail()
+}
+
+// step moves the rope's head end by delta, moves the rest of the rope after it
+// and notifies the observer, if any.
+func (r *Rope) step(delta ds.Point) {
+	r.Head.MoveBy(delta)
+	r.UpdateTail()
+	r.steps++
+	if r.observe != nil {
+		r.observe(StepEvent{Step: r.steps, Delta: delta, Rope: r})
 	}
 }
 
@@ -71,10 +105,9 @@ func (r *Rope) UpdateTail() {
 	for i := 0; i+1 < len(r.Knots); i++ {
 		parent := r.Knots[i]
 		child := r.Knots[i+1]
-		rowDiff := parent.Row - child.Row
-		colDiff := parent.Col - child.Col
-		if abs(rowDiff) > 1 || abs(colDiff) > 1 {
-			child.Move(delta(rowDiff), delta(colDiff))
+		diff := parent.Position().Sub(child.Position())
+		if diff.Chebyshev(ds.Point{}) > 1 {
+			child.MoveBy(diff.Sign())
 		}
 	}
 }
@@ -86,8 +119,8 @@ func (r *Rope) UpdateTail() {
 // error is returned if there is a problem applying a functional option to the
 // Rope struct.
 func NewRope(opts ...Opt) (*Rope, error) {
-	head := &RopeEnd{Row: 0, Col: 0, Visited: map[string]int{"0,0": 1}}
-	tail := &RopeEnd{Row: 0, Col: 0, Visited: map[string]int{"0,0": 1}}
+	head := NewRopeEnd(0, 0)
+	tail := NewRopeEnd(0, 0)
 	rp := &Rope{
 		Head:     head,
 		Tail:     tail,
@@ -101,7 +134,7 @@ func NewRope(opts ...Opt) (*Rope, error) {
 	}
 	knots := []*RopeEnd{head}
 	for i := 2; i < rp.NumKnots; i++ {
-		knots = append(knots, &RopeEnd{Visited: map[string]int{"0,0": 1}})
+		knots = append(knots, NewRopeEnd(0, 0))
 	}
 	knots = append(knots, tail)
 	rp.Knots = knots
@@ -129,37 +162,61 @@ func RopeFromRopeEnds(ropeEnds ...*RopeEnd) (*Rope, error) {
 
 // RopeEnd represents the end of a rope. It contains fields to indicate the rope
 // end's position by row and coordinate and also contains a history of all
-// positions that it has visited.
+// positions that it has visited, counting the visits to each position.
 type RopeEnd struct {
 	Row     int
 	Col     int
 	Visited map[string]int
 }
 
+// Position returns the position of the rope end.
+func (r *RopeEnd) Position() ds.Point {
+	return ds.Point{Row: r.Row, Col: r.Col}
+}
+
 // Move accepts a number of rows and number of columns to move, moves the rope
 // end to that position, and adds the new position to the map of all visited
-// positions.
+// positions. It is a convenience wrapper around MoveBy.
 func (r *RopeEnd) Move(rowDelta, colDelta int) {
-	r.Row += rowDelta
-	r.Col += colDelta
-	key := strconv.Itoa(r.Row) + "," + strconv.Itoa(r.Col)
-	_, ok := r.Visited[key]
-	if !ok {
-		r.Visited[key] = 0
+	r.MoveBy(ds.Point{Row: rowDelta, Col: colDelta})
+}
+
+// MoveBy accepts an offset, moves the rope end by it, and adds the new
+// position to the map of all visited positions.
+func (r *RopeEnd) MoveBy(offset ds.Point) {
+	pos := r.Position().Add(offset)
+	r.Row, r.Col = pos.Row, pos.Col
+	r.Visited[pos.String()]++
+}
+
+// VisitedPoints returns the visited positions of the rope end keyed by
+// position instead of by their "row,col" string form. Keys of Visited that are
+// not in that form are left out.
+func (r *RopeEnd) VisitedPoints() map[ds.Point]int {
+	visited := make(map[ds.Point]int, len(r.Visited))
+	for key, n := range r.Visited {
+		pos, err := ds.ParsePoint(key)
+		if err != nil {
+			continue
+		}
+		visited[pos] = n
 	}
-	r.Visited[key] += 1
+	return visited
 }
 
 // NewRopeEnd accepts a row and column as integers and returns a RopeEnd struct
 // that is initialized to that position.
 func NewRopeEnd(row, col int) *RopeEnd {
-	visited := map[string]int{
-		strconv.Itoa(row) + "," + strconv.Itoa(col): 1,
-	}
+	return NewRopeEndAt(ds.Point{Row: row, Col: col})
+}
+
+// NewRopeEndAt accepts a position and returns a RopeEnd struct that is
+// initialized to that position.
+func NewRopeEndAt(pos ds.Point) *RopeEnd {
 	return &RopeEnd{
-		Row:     row,
-		Col:     col,
-		Visited: visited,
+		Row:     pos.Row,
+		Col:     pos.Col,
+		Visited: map[string]int{pos.String(): 1},
 	}
 }
 
@@ -173,18 +230,19 @@ func NewRopeEnd(row, col int) *RopeEnd {
 // direction to move, with negative values indicating a move down for rows and a
 // move left for columns. An error is returned if the line does not contain at least
 // 2 fields, if the first field is not a valid direction letter, or if the quantity
-// field is not a valid integer.
+// field is not a valid integer. The error holds a *textio.ParseError giving the
+// column of the offending field.
 func HeadMovementFromLine(line string) (numRows, numCols int, err error) {
-	fields := strings.Fields(line)
+	fields := textio.Fields(line)
 	if len(fields) < 2 {
-		err = fmt.Errorf(`line must be in the form "<U|D|R|L> <qty>" (got line "%s")`, line)
+		err = textio.FieldError(1, line, fmt.Errorf(`line must be in the form "<U|D|R|L> <qty>" (got line "%s")`, line))
 		return
 	}
-	qty, err := strconv.Atoi(fields[1])
+	qty, err := strconv.Atoi(fields[1].Text)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, textio.FieldError(fields[1].Column, fields[1].Text, err)
 	}
-	direction := fields[0]
+	direction := fields[0].Text
 	switch {
 	case direction == "U":
 		numRows += qty
@@ -195,7 +253,7 @@ func HeadMovementFromLine(line string) (numRows, numCols int, err error) {
 	case direction == "R":
 		numCols += qty
 	default:
-		err = fmt.Errorf("direction must be one of U, D, R, L (got %s)", direction)
+		err = textio.FieldError(fields[0].Column, direction, fmt.Errorf("direction must be one of U, D, R, L (got %s)", direction))
 	}
 	return
 }
@@ -208,8 +266,15 @@ func HeadMovementFromLine(line string) (numRows, numCols int, err error) {
 // error is returned if the instructions argument is nil, if a movement line is
 // invalidly formatted, if there is a problem reading from the instructions, or
 // if an invalid value is given for the numKnots argument (any value smaller than
-// 2).
-func Run(instructions io.Reader, numKnots int) (*Rope, error) {
+// 2). Errors in the instructions are returned as a *textio.ParseError giving
+// the position of the offending line.
+func Run(instructions io.Reader, numKnots int, opts ...textio.Option) (*Rope, error) {
+	return RunContext(context.Background(), instructions, numKnots, opts...)
+}
+
+// RunContext behaves like Run but checks ctx before applying each movement
+// instruction and returns ctx.Err() if ctx has been cancelled.
+func RunContext(ctx context.Context, instructions io.Reader, numKnots int, opts ...textio.Option) (*Rope, error) {
 	if instructions == nil {
 		return nil, errors.New("instructions argument must be non-nil")
 	}
@@ -217,20 +282,36 @@ func Run(instructions io.Reader, numKnots int) (*Rope, error) {
 	if err != nil {
 		return nil, err
 	}
-	scn := bufio.NewScanner(instructions)
+	if err := rp.Apply(ctx, instructions, opts...); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// Apply accepts an io.Reader pointing to line-separated movement instructions
+// and applies them to the head end of the rope, checking ctx before each
+// instruction. Errors are returned as described for RunContext. A rope created
+// with WithObserver passes a StepEvent to its observer after every step of the
+// head.
+func (r *Rope) Apply(ctx context.Context, instructions io.Reader, opts ...textio.Option) error {
+	if instructions == nil {
+		return errors.New("instructions argument must be non-nil")
+	}
+	scn := textio.NewScanner(instructions, opts...)
 	for scn.Scan() {
-		line := scn.Text()
-		numRows, numCols, err := HeadMovementFromLine(line)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		numRows, numCols, err := HeadMovementFromLine(scn.Text())
 		if err != nil {
-			return nil, err
+			if err := scn.Skip(err); err != nil {
+				return err
+			}
+			continue
 		}
-		rp.MoveHead(numRows, numCols)
-	}
-	err = scn.Err()
-	if err != nil {
-		return nil, err
+		r.MoveHead(numRows, numCols)
 	}
-	return rp, nil
+	return scn.Err()
 }
 
 // abs accepts an integer and returns its absolute value.
@@ -240,15 +321,3 @@ func abs(x int) int {
 	}
 	return x
 }
-
-// delta accepts an integer and returns a delta value based on the sign of x.
-func delta(x int) int {
-	switch {
-	case x == 0:
-		return 0
-	case x < 0:
-		return -1
-	default:
-		return 1
-	}
-}
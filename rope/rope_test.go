@@ -1,9 +1,14 @@
 package rope_test
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/aculclasure/aoc2022/ds"
 	"github.com/aculclasure/aoc2022/rope"
+	"github.com/aculclasure/aoc2022/textio"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -361,3 +366,120 @@ func TestHeadMovementFromLine(t *testing.T) {
 		})
 	}
 }
+
+func TestRunReturnsParseErrorForInvalidLine(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input      string
+		wantLine   int
+		wantColumn int
+	}{
+		"Invalid direction reports the direction column": {
+			input:      "R 4\nU 4\nX 3\n",
+			wantLine:   3,
+			wantColumn: 1,
+		},
+		"Invalid quantity reports the quantity column": {
+			input:      "R 4\nU  four\n",
+			wantLine:   2,
+			wantColumn: 4,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := rope.Run(strings.NewReader(tc.input), 2)
+			var pe *textio.ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("want a *textio.ParseError, got %v", err)
+			}
+			if tc.wantLine != pe.Line || tc.wantColumn != pe.Column {
+				t.Errorf("want line %d, column %d, got line %d, column %d", tc.wantLine, tc.wantColumn, pe.Line, pe.Column)
+			}
+		})
+	}
+}
+
+func TestRunWithMaxLineLengthRejectsLongLines(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader("R 4\nU " + strings.Repeat("1", 1<<20) + "\n")
+	_, err := rope.Run(input, 2, textio.WithMaxLineLength(1024))
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError, got %v", err)
+	}
+	if pe.Line != 2 {
+		t.Errorf("want error on line 2, got line %d", pe.Line)
+	}
+}
+
+func TestRunContextWithCancelledContextReturnsContextError(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := rope.RunContext(ctx, strings.NewReader("R 4\nU 4\n"), 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestRope_MoveHeadCallsObserverAfterEachStep(t *testing.T) {
+	t.Parallel()
+	var got []rope.StepEvent
+	rp, err := rope.NewRope(rope.WithObserver(func(e rope.StepEvent) {
+		e.Rope = nil
+		got = append(got, e)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rp.MoveHead(2, 0)
+	rp.MoveHead(0, -1)
+	want := []rope.StepEvent{
+		{Step: 1, Delta: ds.Point{Row: 1}},
+		{Step: 2, Delta: ds.Point{Row: 1}},
+		{Step: 3, Delta: ds.Point{Col: -1}},
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRope_ApplyPassesStepEventsToObserver(t *testing.T) {
+	t.Parallel()
+	type position struct{ HeadRow, HeadCol, TailRow, TailCol int }
+	var got []position
+	rp, err := rope.NewRope(rope.WithObserver(func(e rope.StepEvent) {
+		got = append(got, position{e.Rope.Head.Row, e.Rope.Head.Col, e.Rope.Tail.Row, e.Rope.Tail.Col})
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rp.Apply(context.Background(), strings.NewReader("R 2\nU 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []position{
+		{0, 1, 0, 0},
+		{0, 2, 0, 1},
+		{1, 2, 0, 1},
+		{2, 2, 1, 2},
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRopeEnd_VisitedPointsKeysPositionsByPoint(t *testing.T) {
+	t.Parallel()
+	end := rope.NewRopeEndAt(ds.Point{Row: 1, Col: -1})
+	end.MoveBy(ds.Point{Col: 1})
+	end.Move(0, -1)
+	want := map[ds.Point]int{{Row: 1, Col: -1}: 2, {Row: 1, Col: 0}: 1}
+	got := end.VisitedPoints()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+	if pos := end.Position(); pos != (ds.Point{Row: 1, Col: -1}) {
+		t.Errorf("want position 1,-1, got %v", pos)
+	}
+}
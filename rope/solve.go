@@ -0,0 +1,30 @@
+package rope
+
+import (
+	"context"
+	"io"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func init() {
+	solver.Register(solver.NewContext(9, 1, solveTailVisits(2)))
+	solver.Register(solver.NewContext(9, 2, solveTailVisits(10)))
+	solver.RegisterGenerator(9, GenerateMotions)
+}
+
+// solveTailVisits returns a solver.ContextFunc that counts the positions
+// visited by the tail of a rope with numKnots knots.
+func solveTailVisits(numKnots int) solver.ContextFunc {
+	return func(ctx context.Context, input io.Reader) (solver.Answer, error) {
+		input, err := solver.NonEmpty(input)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		r, err := RunContext(ctx, input, numKnots)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.IntAnswer(len(r.Tail.Visited)), nil
+	}
+}
@@ -0,0 +1,27 @@
+package rps
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// GenerateStrategyGuide writes a random strategy guide with numRounds rounds
+// to w. Each line holds an opponent play (A, B or C) and a response (X, Y or
+// Z). An error is returned if numRounds is smaller than 1 or if there is a
+// problem writing to w.
+func GenerateStrategyGuide(w io.Writer, rng *rand.Rand, numRounds int) error {
+	if numRounds < 1 {
+		return fmt.Errorf("number of rounds must be at least 1 (got %d)", numRounds)
+	}
+	const (
+		opponentPlays = "ABC"
+		responses     = "XYZ"
+	)
+	bw := bufio.NewWriter(w)
+	for i := 0; i < numRounds; i++ {
+		fmt.Fprintf(bw, "%c %c\n", opponentPlays[rng.Intn(3)], responses[rng.Intn(3)])
+	}
+	return bw.Flush()
+}
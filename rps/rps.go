@@ -1,11 +1,11 @@
 package rps
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
-	"strings"
+
+	"github.com/aculclasure/aoc2022/textio"
 )
 
 type Game struct {
@@ -111,52 +111,95 @@ func (g Game) CheatMatchOutcome(opponentPlay, cheatMatchResult string) (int, err
 	}
 }
 
-func ComputeStrategyScore(strategy io.Reader) (int, error) {
+// ComputeStrategyScore accepts an io.Reader pointing to a strategy guide with
+// one "<opponent play> <response play>" line per round and returns the total
+// score of following it. Blank lines are ignored. A line without exactly two
+// plays, or with an unknown play, is reported as a *textio.ParseError.
+func ComputeStrategyScore(strategy io.Reader, opts ...textio.Option) (int, error) {
 	if strategy == nil {
 		return 0, errors.New("strategy must point to a non-nil strategy source")
 	}
 
 	game := NewGame()
-	sc := bufio.NewScanner(strategy)
+	sc := textio.NewScanner(strategy, opts...)
 	score := 0
 	for sc.Scan() {
-		line := strings.TrimSpace(sc.Text())
-		fields := strings.Fields(line)
+		fields := textio.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
 		if len(fields) != 2 {
+			if err := sc.Skip(strategyLineError(sc.Text())); err != nil {
+				return 0, err
+			}
 			continue
 		}
 		opponentPlay, responsePlay := fields[0], fields[1]
-		matchScore, err := game.MatchOutcome(opponentPlay, responsePlay)
+		matchScore, err := game.MatchOutcome(opponentPlay.Text, responsePlay.Text)
 		if err != nil {
-			return 0, err
+			if err := sc.Skip(game.playError(opponentPlay, responsePlay, err)); err != nil {
+				return 0, err
+			}
+			continue
 		}
 		score += matchScore
 	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
 
 	return score, nil
 }
 
-func ComputeCheatStrategyScore(strategy io.Reader) (int, error) {
+// ComputeCheatStrategyScore behaves like ComputeStrategyScore except that the
+// second play on each line is the outcome the round must end with.
+func ComputeCheatStrategyScore(strategy io.Reader, opts ...textio.Option) (int, error) {
 	if strategy == nil {
 		return 0, errors.New("strategy must point to a non-nil strategy source")
 	}
 
 	game := NewGame()
-	sc := bufio.NewScanner(strategy)
+	sc := textio.NewScanner(strategy, opts...)
 	score := 0
 	for sc.Scan() {
-		line := strings.TrimSpace(sc.Text())
-		fields := strings.Fields(line)
+		fields := textio.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
 		if len(fields) != 2 {
+			if err := sc.Skip(strategyLineError(sc.Text())); err != nil {
+				return 0, err
+			}
 			continue
 		}
 		opponentPlay, responsePlay := fields[0], fields[1]
-		matchScore, err := game.CheatMatchOutcome(opponentPlay, responsePlay)
+		matchScore, err := game.CheatMatchOutcome(opponentPlay.Text, responsePlay.Text)
 		if err != nil {
-			return 0, err
+			if err := sc.Skip(game.playError(opponentPlay, responsePlay, err)); err != nil {
+				return 0, err
+			}
+			continue
 		}
 		score += matchScore
 	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
 
 	return score, nil
 }
+
+// strategyLineError returns the error for a strategy line that does not hold
+// exactly 2 plays.
+func strategyLineError(line string) error {
+	return fmt.Errorf(`line must be in the form "<opponent play> <response play>" (got %q)`, line)
+}
+
+// playError accepts the fields of a strategy line and the error returned when
+// scoring them and returns the error positioned at the offending field.
+func (g Game) playError(opponentPlay, responsePlay textio.Field, err error) error {
+	if _, ok := g.opponentWinRules[opponentPlay.Text]; !ok {
+		return textio.FieldError(opponentPlay.Column, opponentPlay.Text, err)
+	}
+	return textio.FieldError(responsePlay.Column, responsePlay.Text, err)
+}
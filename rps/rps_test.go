@@ -1,10 +1,13 @@
 package rps_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/aculclasure/aoc2022/rps"
+	"github.com/aculclasure/aoc2022/textio"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestMatchOutcomeErrorCases(t *testing.T) {
@@ -136,3 +139,39 @@ C Z
 		t.Errorf("want %d, got %d", want, got)
 	}
 }
+
+func TestComputeStrategyScoreWithMalformedLines(t *testing.T) {
+	t.Parallel()
+	const input = `A Y
+B
+C Z
+
+D X
+`
+	_, err := rps.ComputeStrategyScore(strings.NewReader(input))
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError in strict mode, got %v", err)
+	}
+	if pe.Line != 2 {
+		t.Errorf("want error on line 2, got line %d", pe.Line)
+	}
+
+	var diags textio.Diagnostics
+	got, err := rps.ComputeStrategyScore(strings.NewReader(input), textio.Lenient(), textio.WithDiagnostics(&diags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 14
+	if want != got {
+		t.Errorf("want %d, got %d", want, got)
+	}
+	var gotLines []int
+	for _, d := range diags.List {
+		gotLines = append(gotLines, d.Err.Line)
+	}
+	wantLines := []int{2, 5}
+	if !cmp.Equal(wantLines, gotLines) {
+		t.Error(cmp.Diff(wantLines, gotLines))
+	}
+}
@@ -0,0 +1,37 @@
+package rps
+
+import (
+	"io"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+func init() {
+	solver.Register(solver.New(2, 1, solveDay2Part1))
+	solver.Register(solver.New(2, 2, solveDay2Part2))
+	solver.RegisterGenerator(2, GenerateStrategyGuide)
+}
+
+func solveDay2Part1(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	score, err := ComputeStrategyScore(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(score), nil
+}
+
+func solveDay2Part2(input io.Reader) (solver.Answer, error) {
+	input, err := solver.NonEmpty(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	score, err := ComputeCheatStrategyScore(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(score), nil
+}
@@ -0,0 +1,157 @@
+// Package scaffold generates the skeleton of a new puzzle day: a package with
+// placeholder solutions, a placeholder example input and a test file for it,
+// and the registration of the package's solvers. The puzzle input itself is
+// not generated; it belongs in the input store once it has been downloaded.
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// pkgNameRgx defines what a valid package name for a new day looks like.
+var pkgNameRgx = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// Config holds the settings used to generate a new day.
+type Config struct {
+	// Root is the root directory of the module.
+	Root string
+	// Module is the module path that the generated imports are relative to.
+	Module string
+	// Day is the puzzle day (1-25).
+	Day int
+	// Package is the name of the package to generate.
+	Package string
+}
+
+// File represents a generated file. Path is relative to the module root.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Files accepts a Config and returns the files that make up a new day without
+// writing them. An error is returned if the config is invalid or a template
+// cannot be rendered.
+func Files(cfg Config) ([]File, error) {
+	if cfg.Day < 1 || cfg.Day > 25 {
+		return nil, fmt.Errorf("day must be between 1 and 25 inclusive (got %d)", cfg.Day)
+	}
+	if !pkgNameRgx.MatchString(cfg.Package) {
+		return nil, fmt.Errorf("package name must be lowercase letters and digits starting with a letter (got %q)", cfg.Package)
+	}
+	if cfg.Module == "" {
+		return nil, errors.New("module path must be non-empty")
+	}
+	goFiles := []struct {
+		path     string
+		template string
+	}{
+		{path: filepath.Join(cfg.Package, cfg.Package+".go"), template: "package.go.tmpl"},
+		{path: filepath.Join(cfg.Package, cfg.Package+"_test.go"), template: "package_test.go.tmpl"},
+		{path: filepath.Join(cfg.Package, "solve.go"), template: "solve.go.tmpl"},
+		{path: filepath.Join("puzzles", cfg.Package+".go"), template: "import.go.tmpl"},
+	}
+	var files []File
+	for _, gf := range goFiles {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, gf.template, cfg); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %w", gf.path, err)
+		}
+		files = append(files, File{Path: gf.path, Content: src})
+	}
+	files = append(files, File{
+		Path:    filepath.Join(cfg.Package, "testdata", fmt.Sprintf("day%02d-example.txt", cfg.Day)),
+		Content: []byte("TODO: paste the example input here.\n"),
+	})
+	return files, nil
+}
+
+// Generate accepts a Config, writes the files for a new day beneath cfg.Root
+// and returns the paths of the written files. No files are written and an error
+// is returned if any of the files already exists.
+func Generate(cfg Config) ([]string, error) {
+	files, err := Files(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var existing []string
+	for _, f := range files {
+		_, err := os.Stat(filepath.Join(cfg.Root, f.Path))
+		switch {
+		case err == nil:
+			existing = append(existing, f.Path)
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("refusing to overwrite existing files: %s", strings.Join(existing, ", "))
+	}
+	var written []string
+	for _, f := range files {
+		path := filepath.Join(cfg.Root, f.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, err
+		}
+		if err := writeNewFile(path, f.Content); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// writeNewFile writes data to a file at path that must not already exist.
+func writeNewFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ModulePath accepts the root directory of a module and returns the module
+// path declared in its go.mod file. An error is returned if the go.mod file
+// cannot be read or does not declare a module path.
+func ModulePath(root string) (string, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scn := bufio.NewScanner(f)
+	for scn.Scan() {
+		fields := strings.Fields(scn.Text())
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+	if err := scn.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s must declare a module path", filepath.Join(root, "go.mod"))
+}
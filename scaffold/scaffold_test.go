@@ -0,0 +1,102 @@
+package scaffold_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/scaffold"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateWritesNewDayFiles(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	cfg := scaffold.Config{Root: root, Module: "example.com/aoc", Day: 12, Package: "hills"}
+	written, err := scaffold.Generate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(root, "hills", "hills.go"),
+		filepath.Join(root, "hills", "hills_test.go"),
+		filepath.Join(root, "hills", "solve.go"),
+		filepath.Join(root, "puzzles", "hills.go"),
+		filepath.Join(root, "hills", "testdata", "day12-example.txt"),
+	}
+	if !cmp.Equal(want, written) {
+		t.Error(cmp.Diff(want, written))
+	}
+	solve, err := os.ReadFile(filepath.Join(root, "hills", "solve.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(solve), "solver.Register(solver.New(12, 1, solveDay12Part1))") {
+		t.Errorf("want solve.go to register the day 12 solvers, got:\n%s", solve)
+	}
+	pkg, err := os.ReadFile(filepath.Join(root, "hills", "hills.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(pkg), "textio.NewScanner(input, opts...)") {
+		t.Errorf("want hills.go to read the input with a textio.Scanner, got:\n%s", pkg)
+	}
+}
+
+func TestGenerateRefusesToOverwriteExistingFiles(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	existing := filepath.Join(root, "hills", "hills.go")
+	if err := os.MkdirAll(filepath.Dir(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("package hills\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := scaffold.Config{Root: root, Module: "example.com/aoc", Day: 12, Package: "hills"}
+	_, err := scaffold.Generate(cfg)
+	if err == nil {
+		t.Fatal("expected an error but did not get one")
+	}
+	got, err := os.ReadFile(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "package hills\n" {
+		t.Errorf("existing file was overwritten, got:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(root, "hills", "solve.go")); err == nil {
+		t.Error("want no files to be written when a file already exists")
+	}
+}
+
+func TestFilesErrorCases(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]scaffold.Config{
+		"Day out of range returns error":         {Module: "example.com/aoc", Day: 26, Package: "hills"},
+		"Invalid package name returns error":     {Module: "example.com/aoc", Day: 12, Package: "Hills"},
+		"Empty module path returns error":        {Day: 12, Package: "hills"},
+		"Package name with dashes returns error": {Module: "example.com/aoc", Day: 12, Package: "hill-climb"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := scaffold.Files(tc)
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestModulePath(t *testing.T) {
+	t.Parallel()
+	want := "github.com/aculclasure/aoc2022"
+	got, err := scaffold.ModulePath("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want != got {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,205 @@
+// Package server exposes the solvers in a registry over HTTP. It serves two
+// endpoints:
+//
+//	GET  /days                    lists the registered solvers
+//	POST /2022/day/{n}/part/{p}   solves a part for the input in the request body
+//
+// Solutions are returned as the JSON encoding of a solver.Result and errors as
+// a JSON object with an error field.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aculclasure/aoc2022/inputs"
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+const (
+	// DefaultMaxInputBytes is the largest puzzle input accepted when a Handler
+	// does not set MaxInputBytes.
+	DefaultMaxInputBytes = 10 << 20
+	// DefaultTimeout is how long a solver may run when a Handler does not set
+	// Timeout.
+	DefaultTimeout = 30 * time.Second
+	// StatusClientClosedRequest is the non-standard status code written when
+	// the client cancels a request before its solver finishes. The client
+	// does not see it, but it tells the cancellation apart from a timeout in
+	// access logs and middleware.
+	StatusClientClosedRequest = 499
+)
+
+// Handler is an http.Handler that serves the solvers in a registry.
+type Handler struct {
+	// Registry holds the solvers to serve. The solver.DefaultRegistry is used
+	// when it is nil.
+	Registry *solver.Registry
+	// MaxInputBytes is the largest request body accepted as a puzzle input.
+	MaxInputBytes int64
+	// Timeout is how long a solver may run before the request fails with a
+	// 504 Gateway Timeout status. The solver's context is cancelled at that
+	// point, but a solver that ignores its context keeps running in the
+	// background until it returns. Timeout bounds how long a client waits,
+	// not the CPU time spent on a request.
+	Timeout time.Duration
+}
+
+// DayInfo describes the solvers registered for a day in the response to
+// GET /days.
+type DayInfo struct {
+	Day   int   `json:"day"`
+	Parts []int `json:"parts"`
+}
+
+// ServeHTTP routes a request to the matching endpoint.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/days" {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeMethodNotAllowed(w, http.MethodGet, http.MethodHead)
+			return
+		}
+		h.listDays(w)
+		return
+	}
+	day, part, ok := parseSolvePath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no endpoint exists for %s", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, http.MethodPost)
+		return
+	}
+	h.solve(w, r, day, part)
+}
+
+// listDays writes the registered solvers grouped by day.
+func (h *Handler) listDays(w http.ResponseWriter) {
+	days := []DayInfo{}
+	for _, s := range h.registry().All() {
+		if n := len(days); n > 0 && days[n-1].Day == s.Day() {
+			days[n-1].Parts = append(days[n-1].Parts, s.Part())
+			continue
+		}
+		days = append(days, DayInfo{Day: s.Day(), Parts: []int{s.Part()}})
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Days []DayInfo `json:"days"`
+	}{Days: days})
+}
+
+// solve runs the solver for the given day and part against the request body
+// and writes the result. A solver that panics fails the request with a 500
+// Internal Server Error status instead of stopping the server. A request that
+// the client cancels before the solver finishes fails with
+// StatusClientClosedRequest.
+func (h *Handler) solve(w http.ResponseWriter, r *http.Request, day, part int) {
+	s, ok := h.registry().Lookup(day, part)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no solution exists for day %d, part %d", day, part))
+		return
+	}
+	maxBytes := h.MaxInputBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxInputBytes
+	}
+	input, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("reading input: %w", err))
+		return
+	}
+	if int64(len(input)) > maxBytes {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("input must not be larger than %d bytes", maxBytes))
+		return
+	}
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	// The result channel is buffered so that a solver that finishes after
+	// the request has timed out does not block forever.
+	results := make(chan solver.Result, 1)
+	go func() {
+		results <- solver.RunContext(ctx, s, "", input)
+	}()
+	var res solver.Result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		res = solver.Result{Day: day, Part: part, Err: ctx.Err(), InputSHA256: solver.Checksum(input)}
+	}
+	// A solver that stops because ctx is done returns ctx.Err() itself, so
+	// the status is chosen from the error rather than from the select case
+	// that won.
+	var panicErr solver.PanicError
+	switch {
+	case res.Err == nil:
+		writeJSON(w, http.StatusOK, res)
+	case errors.Is(res.Err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, fmt.Errorf("day %d, part %d did not finish within %s", day, part, timeout))
+	case errors.Is(res.Err, context.Canceled) && r.Context().Err() != nil:
+		writeError(w, StatusClientClosedRequest, fmt.Errorf("request for day %d, part %d was cancelled by the client", day, part))
+	case errors.As(res.Err, &panicErr):
+		writeJSON(w, http.StatusInternalServerError, res)
+	default:
+		writeJSON(w, http.StatusUnprocessableEntity, res)
+	}
+}
+
+// registry returns the registry holding the solvers to serve.
+func (h *Handler) registry() *solver.Registry {
+	if h.Registry == nil {
+		return solver.DefaultRegistry
+	}
+	return h.Registry
+}
+
+// parseSolvePath accepts a URL path in the form /2022/day/{n}/part/{p} and
+// returns the day and part. The returned boolean is false if the path is not
+// in that form.
+func parseSolvePath(path string) (day, part int, ok bool) {
+	fields := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(fields) != 5 || fields[0] != strconv.Itoa(inputs.Year) || fields[1] != "day" || fields[3] != "part" {
+		return 0, 0, false
+	}
+	day, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return 0, 0, false
+	}
+	part, err = strconv.Atoi(fields[4])
+	if err != nil {
+		return 0, 0, false
+	}
+	return day, part, true
+}
+
+// writeJSON writes v to w as a JSON document with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes err to w as a JSON object with an error field.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+// writeMethodNotAllowed writes a 405 Method Not Allowed response listing the
+// allowed methods.
+func writeMethodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method must be one of %s", strings.Join(allowed, ", ")))
+}
@@ -0,0 +1,257 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aculclasure/aoc2022/server"
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func answerLength(input io.Reader) (solver.Answer, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(len(data)), nil
+}
+
+// newHandler returns a Handler serving test solvers. Day 3 part 1 blocks until
+// release is closed.
+func newHandler(release chan struct{}) *server.Handler {
+	r := solver.NewRegistry()
+	r.Register(solver.New(1, 1, answerLength))
+	r.Register(solver.New(1, 2, func(io.Reader) (solver.Answer, error) {
+		return solver.Answer{}, errors.New("bad input")
+	}))
+	r.Register(solver.New(3, 1, func(io.Reader) (solver.Answer, error) {
+		<-release
+		return solver.IntAnswer(0), nil
+	}))
+	return &server.Handler{Registry: r, MaxInputBytes: 8, Timeout: 50 * time.Millisecond}
+}
+
+func TestHandler_ListsRegisteredDays(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	defer close(release)
+	rec := httptest.NewRecorder()
+	newHandler(release).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/days", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var got struct {
+		Days []server.DayInfo `json:"days"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []server.DayInfo{{Day: 1, Parts: []int{1, 2}}, {Day: 3, Parts: []int{1}}}
+	if !cmp.Equal(want, got.Days) {
+		t.Error(cmp.Diff(want, got.Days))
+	}
+}
+
+func TestHandler_SolveReturnsJSONAnswer(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	defer close(release)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/2022/day/1/part/1", strings.NewReader("abcd"))
+	newHandler(release).ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("want content type application/json, got %s", got)
+	}
+	var got solver.Result
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Day != 1 || got.Part != 1 || !cmp.Equal(solver.IntAnswer(4), got.Answer) {
+		t.Errorf("want day 1, part 1 with answer 4, got %+v", got)
+	}
+	if want := solver.Checksum([]byte("abcd")); got.InputSHA256 != want {
+		t.Errorf("want input checksum %s, got %s", want, got.InputSHA256)
+	}
+}
+
+func TestHandler_ErrorResponses(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		"Unknown path returns not found": {
+			method: http.MethodGet,
+			path:   "/2022/day/1",
+			want:   http.StatusNotFound,
+		},
+		"Non-numeric day returns not found": {
+			method: http.MethodPost,
+			path:   "/2022/day/one/part/1",
+			want:   http.StatusNotFound,
+		},
+		"Other year returns not found": {
+			method: http.MethodPost,
+			path:   "/2021/day/1/part/1",
+			want:   http.StatusNotFound,
+		},
+		"Unregistered solver returns not found": {
+			method: http.MethodPost,
+			path:   "/2022/day/2/part/1",
+			want:   http.StatusNotFound,
+		},
+		"GET on a solver returns method not allowed": {
+			method: http.MethodGet,
+			path:   "/2022/day/1/part/1",
+			want:   http.StatusMethodNotAllowed,
+		},
+		"POST on days returns method not allowed": {
+			method: http.MethodPost,
+			path:   "/days",
+			want:   http.StatusMethodNotAllowed,
+		},
+		"Input larger than the limit returns request entity too large": {
+			method: http.MethodPost,
+			path:   "/2022/day/1/part/1",
+			body:   "123456789",
+			want:   http.StatusRequestEntityTooLarge,
+		},
+		"Solver error returns unprocessable entity": {
+			method: http.MethodPost,
+			path:   "/2022/day/1/part/2",
+			want:   http.StatusUnprocessableEntity,
+		},
+		"Solver that runs past the timeout returns gateway timeout": {
+			method: http.MethodPost,
+			path:   "/2022/day/3/part/1",
+			want:   http.StatusGatewayTimeout,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			newHandler(release).ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("want status %d, got %d", tc.want, rec.Code)
+			}
+			var got struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Error == "" {
+				t.Error("want an error message in the response but did not get one")
+			}
+		})
+	}
+}
+
+func TestHandler_CancelsContextSolverAfterTimeout(t *testing.T) {
+	t.Parallel()
+	stopped := make(chan error, 1)
+	r := solver.NewRegistry()
+	r.Register(solver.NewContext(4, 1, func(ctx context.Context, _ io.Reader) (solver.Answer, error) {
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return solver.Answer{}, ctx.Err()
+	}))
+	h := &server.Handler{Registry: r, Timeout: 10 * time.Millisecond}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/2022/day/4/part/1", strings.NewReader("")))
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("want status %d, got %d", http.StatusGatewayTimeout, rec.Code)
+	}
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("want solver to stop with %v, got %v", context.DeadlineExceeded, err)
+		}
+	case <-time.After(time.Second):
+		t.Error("want solver to be stopped after the timeout, but it kept running")
+	}
+}
+
+func TestHandler_RecoversFromPanickingSolver(t *testing.T) {
+	t.Parallel()
+	r := solver.NewRegistry()
+	r.Register(solver.New(5, 1, func(io.Reader) (solver.Answer, error) {
+		panic("boom")
+	}))
+	r.Register(solver.New(5, 2, answerLength))
+	h := &server.Handler{Registry: r}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/2022/day/5/part/1", strings.NewReader("Monkey 0:")))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("want status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	var got struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got.Error, "boom") {
+		t.Errorf("want an error message mentioning the panic, got %q", got.Error)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/2022/day/5/part/2", strings.NewReader("abc")))
+	if rec.Code != http.StatusOK {
+		t.Errorf("want status %d after a panicking solver, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestHandler_ReturnsGatewayTimeoutWhenSolverReturnsDeadlineError(t *testing.T) {
+	t.Parallel()
+	r := solver.NewRegistry()
+	r.Register(solver.NewContext(4, 1, func(ctx context.Context, _ io.Reader) (solver.Answer, error) {
+		<-ctx.Done()
+		return solver.Answer{}, fmt.Errorf("stopped after round 3: %w", ctx.Err())
+	}))
+	h := &server.Handler{Registry: r, Timeout: time.Millisecond}
+	for i := 0; i < 20; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/2022/day/4/part/1", strings.NewReader("")))
+		if rec.Code != http.StatusGatewayTimeout {
+			t.Fatalf("request %d: want status %d, got %d", i, http.StatusGatewayTimeout, rec.Code)
+		}
+	}
+}
+
+func TestHandler_ReturnsClientClosedRequestWhenClientCancels(t *testing.T) {
+	t.Parallel()
+	r := solver.NewRegistry()
+	r.Register(solver.NewContext(4, 1, func(ctx context.Context, _ io.Reader) (solver.Answer, error) {
+		<-ctx.Done()
+		return solver.Answer{}, ctx.Err()
+	}))
+	h := &server.Handler{Registry: r, Timeout: time.Minute}
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodPost, "/2022/day/4/part/1", strings.NewReader("")).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	h.ServeHTTP(rec, req)
+	if rec.Code != server.StatusClientClosedRequest {
+		t.Errorf("want status %d, got %d", server.StatusClientClosedRequest, rec.Code)
+	}
+}
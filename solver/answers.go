@@ -0,0 +1,177 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aculclasure/aoc2022/textio"
+	"github.com/google/go-cmp/cmp"
+)
+
+// partHeaderRgx defines what the header line of a part in an answers file looks
+// like.
+var partHeaderRgx = regexp.MustCompile(`^part (\d+):(.*)$`)
+
+// Answers maps a puzzle part to the text of its expected answer.
+type Answers map[int]string
+
+// ReadAnswers accepts an io.Reader pointing to a recorded set of answers for a
+// single day and returns the parsed Answers. Each answer starts with a header
+// line in the form "part N:". Single-line answers may follow the header on the
+// same line, while multi-line answers (like CRT output) follow on the lines
+// after the header. An error is returned if the data cannot be read, if text
+// appears before the first header or if a part is recorded more than once.
+func ReadAnswers(r io.Reader) (Answers, error) {
+	if r == nil {
+		return nil, errors.New("answers reader must be non-nil")
+	}
+	answers := Answers{}
+	var (
+		part  int
+		lines []string
+	)
+	flush := func() {
+		if part != 0 {
+			answers[part] = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+		}
+	}
+	scn := textio.NewScanner(r)
+	for scn.Scan() {
+		line := scn.Text()
+		submatches := partHeaderRgx.FindStringSubmatch(line)
+		if submatches == nil {
+			if part == 0 {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				return nil, fmt.Errorf(`answers must start with a "part N:" header line (got %s)`, line)
+			}
+			lines = append(lines, line)
+			continue
+		}
+		flush()
+		p, err := strconv.Atoi(submatches[1])
+		if err != nil {
+			return nil, fmt.Errorf("part number in header line must be a valid integer (got %s)", line)
+		}
+		if _, dup := answers[p]; dup {
+			return nil, fmt.Errorf("answer for part %d must only be recorded once", p)
+		}
+		part = p
+		lines = nil
+		if rest := strings.TrimSpace(submatches[2]); rest != "" {
+			lines = append(lines, rest)
+		}
+	}
+	if err := scn.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return answers, nil
+}
+
+// WriteTo writes the answers to w in the format understood by ReadAnswers. It
+// returns the number of bytes written along with any error encountered.
+func (a Answers) WriteTo(w io.Writer) (int64, error) {
+	var parts []int
+	for p := range a {
+		parts = append(parts, p)
+	}
+	sort.Ints(parts)
+	var sb strings.Builder
+	for _, p := range parts {
+		if strings.Contains(a[p], "\n") {
+			fmt.Fprintf(&sb, "part %d:\n%s\n", p, a[p])
+			continue
+		}
+		fmt.Fprintf(&sb, "part %d: %s\n", p, a[p])
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// Status represents the outcome of verifying a solver's answer.
+type Status int
+
+const (
+	// StatusPass indicates the answer matched the expected answer.
+	StatusPass Status = iota
+	// StatusFail indicates the answer did not match the expected answer.
+	StatusFail
+	// StatusError indicates the solver returned an error.
+	StatusError
+	// StatusMissing indicates no expected answer is recorded for the solver.
+	StatusMissing
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusPass:
+		return "PASS"
+	case StatusFail:
+		return "FAIL"
+	case StatusError:
+		return "ERROR"
+	case StatusMissing:
+		return "MISSING"
+	default:
+		return "Status(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// Verification represents the result of comparing a solver's answer to the
+// expected answer.
+type Verification struct {
+	Day    int
+	Part   int
+	Status Status
+	Want   string
+	Got    string
+	Err    error
+}
+
+// Regressed returns true if the solver failed or returned an error.
+func (v Verification) Regressed() bool {
+	return v.Status == StatusFail || v.Status == StatusError
+}
+
+// Diff returns a line-by-line diff between the expected and actual answers. An
+// empty string is returned if the answers match.
+func (v Verification) Diff() string {
+	if v.Want == v.Got {
+		return ""
+	}
+	return cmp.Diff(strings.Split(v.Want, "\n"), strings.Split(v.Got, "\n"))
+}
+
+// Verify accepts a Solver, an io.Reader pointing to the puzzle input and the
+// recorded answers for the solver's day, runs the solver and compares its
+// answer with the recorded answer for the solver's part. A solver that panics
+// is reported with StatusError and a PanicError.
+func Verify(s Solver, input io.Reader, want Answers) Verification {
+	v := Verification{Day: s.Day(), Part: s.Part()}
+	answer, err := solve(s, input)
+	if err != nil {
+		v.Status = StatusError
+		v.Err = err
+		return v
+	}
+	v.Got = answer.String()
+	wantAnswer, ok := want[s.Part()]
+	switch {
+	case !ok:
+		v.Status = StatusMissing
+	case wantAnswer == v.Got:
+		v.Status = StatusPass
+		v.Want = wantAnswer
+	default:
+		v.Status = StatusFail
+		v.Want = wantAnswer
+	}
+	return v
+}
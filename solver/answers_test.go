@@ -0,0 +1,119 @@
+package solver_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadAnswersWithValidInputReturnsExpectedAnswers(t *testing.T) {
+	t.Parallel()
+	input := strings.NewReader(`part 1: 13740
+part 2:
+##..##
+.##..#
+`)
+	want := solver.Answers{
+		1: "13740",
+		2: "##..##\n.##..#",
+	}
+	got, err := solver.ReadAnswers(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestReadAnswersErrorCases(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]string{
+		"Answer without a part header returns error": "13740\n",
+		"Duplicate part header returns error":        "part 1: 1\npart 1: 2\n",
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := solver.ReadAnswers(strings.NewReader(tc))
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestAnswers_WriteToRoundTripsThroughReadAnswers(t *testing.T) {
+	t.Parallel()
+	want := solver.Answers{1: "FGLQJCMBD", 2: "#..#\n.##."}
+	var sb strings.Builder
+	_, err := want.WriteTo(&sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := solver.ReadAnswers(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	echo := solver.New(1, 1, func(input io.Reader) (solver.Answer, error) {
+		data, err := io.ReadAll(input)
+		return solver.StringAnswer(string(data)), err
+	})
+	broken := solver.New(1, 1, func(io.Reader) (solver.Answer, error) {
+		return solver.Answer{}, errors.New("broken")
+	})
+	testCases := map[string]struct {
+		solver    solver.Solver
+		input     string
+		answers   solver.Answers
+		want      solver.Status
+		regressed bool
+	}{
+		"Matching answer passes": {
+			solver:  echo,
+			input:   "42",
+			answers: solver.Answers{1: "42"},
+			want:    solver.StatusPass,
+		},
+		"Mismatched answer fails": {
+			solver:    echo,
+			input:     "41",
+			answers:   solver.Answers{1: "42"},
+			want:      solver.StatusFail,
+			regressed: true,
+		},
+		"Solver error is reported": {
+			solver:    broken,
+			answers:   solver.Answers{1: "42"},
+			want:      solver.StatusError,
+			regressed: true,
+		},
+		"Unrecorded answer is missing": {
+			solver:  echo,
+			input:   "42",
+			answers: solver.Answers{2: "42"},
+			want:    solver.StatusMissing,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := solver.Verify(tc.solver, strings.NewReader(tc.input), tc.answers)
+			if tc.want != got.Status {
+				t.Errorf("want status %s, got %s", tc.want, got.Status)
+			}
+			if tc.regressed != got.Regressed() {
+				t.Errorf("want regressed %t, got %t", tc.regressed, got.Regressed())
+			}
+		})
+	}
+}
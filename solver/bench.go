@@ -0,0 +1,109 @@
+package solver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// BenchResult represents the timing and allocation statistics gathered by
+// running a solver repeatedly.
+type BenchResult struct {
+	Day         int   `json:"day"`
+	Part        int   `json:"part"`
+	Iterations  int   `json:"iterations"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+// Bench accepts a Solver, the contents of a puzzle input and a number of
+// iterations, runs the solver against the input that many times and returns
+// the average time and allocations per run. An error is returned if n is
+// smaller than 1 or if the solver returns an error. A solver that panics
+// returns a PanicError.
+func Bench(s Solver, input []byte, n int) (BenchResult, error) {
+	if n < 1 {
+		return BenchResult{}, fmt.Errorf("number of iterations must be at least 1 (got %d)", n)
+	}
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		if _, err := solve(s, bytes.NewReader(input)); err != nil {
+			return BenchResult{}, err
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return BenchResult{
+		Day:         s.Day(),
+		Part:        s.Part(),
+		Iterations:  n,
+		NsPerOp:     elapsed.Nanoseconds() / int64(n),
+		AllocsPerOp: int64(after.Mallocs-before.Mallocs) / int64(n),
+		BytesPerOp:  int64(after.TotalAlloc-before.TotalAlloc) / int64(n),
+	}, nil
+}
+
+// BenchComparison represents the difference between a baseline benchmark
+// result and a current benchmark result for the same day and part.
+type BenchComparison struct {
+	Baseline BenchResult
+	Current  BenchResult
+	// NsDelta is the relative change in time per run (0.1 means 10% slower).
+	NsDelta float64
+	// AllocsDelta is the relative change in allocations per run.
+	AllocsDelta float64
+	// Regressed is true when either delta exceeds the comparison threshold.
+	Regressed bool
+}
+
+// CompareBench accepts baseline and current benchmark results along with a
+// threshold expressed as a fraction (e.g. 0.1 for 10%) and returns a
+// comparison for every current result that has a matching baseline result. A
+// comparison is marked as regressed when the time or allocations per run grew
+// by more than the threshold. An error is returned if the threshold is
+// negative.
+func CompareBench(baseline, current []BenchResult, threshold float64) ([]BenchComparison, error) {
+	if threshold < 0 {
+		return nil, errors.New("threshold must not be negative")
+	}
+	type key struct{ day, part int }
+	base := make(map[key]BenchResult, len(baseline))
+	for _, b := range baseline {
+		base[key{b.Day, b.Part}] = b
+	}
+	var comparisons []BenchComparison
+	for _, c := range current {
+		b, ok := base[key{c.Day, c.Part}]
+		if !ok {
+			continue
+		}
+		comparison := BenchComparison{
+			Baseline:    b,
+			Current:     c,
+			NsDelta:     relativeChange(b.NsPerOp, c.NsPerOp),
+			AllocsDelta: relativeChange(b.AllocsPerOp, c.AllocsPerOp),
+		}
+		comparison.Regressed = comparison.NsDelta > threshold || comparison.AllocsDelta > threshold
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons, nil
+}
+
+// relativeChange returns the change from old to new as a fraction of old. A
+// zero old value is treated as unchanged when new is also zero and as a 100%
+// increase otherwise.
+func relativeChange(old, new int64) float64 {
+	if old == 0 {
+		if new == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(new-old) / float64(old)
+}
@@ -0,0 +1,99 @@
+package solver_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBenchReturnsResultForSolver(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	s := solver.New(4, 2, func(input io.Reader) (solver.Answer, error) {
+		calls++
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		if string(data) != "input" {
+			return solver.Answer{}, errors.New("solver must receive the full input on every run")
+		}
+		return solver.IntAnswer(len(data)), nil
+	})
+	got, err := solver.Bench(s, []byte("input"), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 5 {
+		t.Errorf("want solver to be called 5 times, got %d", calls)
+	}
+	if got.Day != 4 || got.Part != 2 || got.Iterations != 5 {
+		t.Errorf("want day 4, part 2, 5 iterations, got %+v", got)
+	}
+}
+
+func TestBenchErrorCases(t *testing.T) {
+	t.Parallel()
+	broken := solver.New(1, 1, func(io.Reader) (solver.Answer, error) {
+		return solver.Answer{}, errors.New("broken")
+	})
+	testCases := map[string]struct {
+		solver solver.Solver
+		n      int
+	}{
+		"Zero iterations returns error": {
+			solver: solver.New(1, 1, answerLength),
+			n:      0,
+		},
+		"Solver error is returned": {
+			solver: broken,
+			n:      1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := solver.Bench(tc.solver, nil, tc.n)
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}
+
+func TestCompareBench(t *testing.T) {
+	t.Parallel()
+	baseline := []solver.BenchResult{
+		{Day: 1, Part: 1, NsPerOp: 100, AllocsPerOp: 10},
+		{Day: 1, Part: 2, NsPerOp: 100, AllocsPerOp: 10},
+		{Day: 2, Part: 1, NsPerOp: 100, AllocsPerOp: 10},
+	}
+	current := []solver.BenchResult{
+		{Day: 1, Part: 1, NsPerOp: 105, AllocsPerOp: 10},
+		{Day: 1, Part: 2, NsPerOp: 150, AllocsPerOp: 10},
+		{Day: 2, Part: 1, NsPerOp: 50, AllocsPerOp: 20},
+		{Day: 3, Part: 1, NsPerOp: 50, AllocsPerOp: 20},
+	}
+	comparisons, err := solver.CompareBench(baseline, current, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bool{false, true, true}
+	var got []bool
+	for _, c := range comparisons {
+		got = append(got, c.Regressed)
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestCompareBenchWithNegativeThresholdReturnsError(t *testing.T) {
+	t.Parallel()
+	_, err := solver.CompareBench(nil, nil, -1)
+	if err == nil {
+		t.Error("expected an error but did not get one")
+	}
+}
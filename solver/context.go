@@ -0,0 +1,48 @@
+package solver
+
+import (
+	"context"
+	"io"
+)
+
+// ContextSolver is implemented by solvers that stop early when a context is
+// cancelled, which lets callers like the runner and the HTTP server enforce
+// timeouts.
+type ContextSolver interface {
+	Solver
+	// SolveContext behaves like Solve but returns ctx.Err() if ctx is
+	// cancelled before the answer is found.
+	SolveContext(ctx context.Context, input io.Reader) (Answer, error)
+}
+
+// ContextFunc represents a function that solves one part of a puzzle and stops
+// early when ctx is cancelled.
+type ContextFunc func(ctx context.Context, input io.Reader) (Answer, error)
+
+// contextFuncSolver adapts a ContextFunc to the ContextSolver interface.
+type contextFuncSolver struct {
+	day  int
+	part int
+	fn   ContextFunc
+}
+
+func (f contextFuncSolver) Day() int  { return f.day }
+func (f contextFuncSolver) Part() int { return f.part }
+
+func (f contextFuncSolver) Solve(input io.Reader) (Answer, error) {
+	return f.fn(context.Background(), input)
+}
+
+func (f contextFuncSolver) SolveContext(ctx context.Context, input io.Reader) (Answer, error) {
+	return f.fn(ctx, input)
+}
+
+func (f contextFuncSolver) sourceFunc() any {
+	return f.fn
+}
+
+// NewContext accepts a day, a part and a ContextFunc that solves that part of
+// the puzzle for the given day and returns a ContextSolver.
+func NewContext(day, part int, fn ContextFunc) ContextSolver {
+	return contextFuncSolver{day: day, part: part, fn: fn}
+}
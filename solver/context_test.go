@@ -0,0 +1,87 @@
+package solver_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aculclasure/aoc2022/solver"
+)
+
+// waitForCancel is a solver.ContextFunc that only returns once its context is
+// cancelled.
+func waitForCancel(ctx context.Context, _ io.Reader) (solver.Answer, error) {
+	<-ctx.Done()
+	return solver.Answer{}, ctx.Err()
+}
+
+func TestNewContextSolverStopsWhenContextIsCancelled(t *testing.T) {
+	t.Parallel()
+	s := solver.NewContext(1, 1, waitForCancel)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.SolveContext(ctx, strings.NewReader(""))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestRunContextPassesContextToSolver(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]solver.Solver{
+		"Context solver": solver.NewContext(1, 1, waitForCancel),
+		"Phased context solver": solver.NewPhasedContext(1, 1, readAll, func(ctx context.Context, _ string) (solver.Answer, error) {
+			return waitForCancel(ctx, nil)
+		}),
+	}
+	for name, s := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			got := solver.RunContext(ctx, s, "", []byte("abc"))
+			if !errors.Is(got.Err, context.DeadlineExceeded) {
+				t.Errorf("want error %v, got %v", context.DeadlineExceeded, got.Err)
+			}
+		})
+	}
+}
+
+func TestRunContextDoesNotRunSolverWithCancelledContext(t *testing.T) {
+	t.Parallel()
+	ran := false
+	s := solver.New(1, 1, func(io.Reader) (solver.Answer, error) {
+		ran = true
+		return solver.IntAnswer(1), nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got := solver.RunContext(ctx, s, "", nil)
+	if !errors.Is(got.Err, context.Canceled) {
+		t.Errorf("want error %v, got %v", context.Canceled, got.Err)
+	}
+	if ran {
+		t.Error("want solver to not be run, but it was")
+	}
+}
+
+func TestRunAllContextFailsJobsOnceContextIsCancelled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	jobs := []solver.Job{
+		{Solver: solver.New(1, 1, func(io.Reader) (solver.Answer, error) {
+			cancel()
+			return solver.IntAnswer(1), nil
+		})},
+		{Solver: solver.New(1, 2, answerLength)},
+	}
+	got := solver.RunAllContext(ctx, jobs, 1)
+	if got[0].Err != nil {
+		t.Errorf("want first job to succeed, got %v", got[0].Err)
+	}
+	if !errors.Is(got[1].Err, context.Canceled) {
+		t.Errorf("want second job to fail with %v, got %v", context.Canceled, got[1].Err)
+	}
+}
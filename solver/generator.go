@@ -0,0 +1,72 @@
+package solver
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+// Generator writes a random but valid puzzle input for a day to w. The size
+// controls how large the input is and its meaning depends on the day (e.g. the
+// number of elves or the number of instructions). Generators draw every random
+// value from rng so that the same seed always produces the same input.
+type Generator func(w io.Writer, rng *rand.Rand, size int) error
+
+// RegisterGenerator accepts a day and a Generator of puzzle inputs for that
+// day and adds it to the registry. It panics if the generator is nil, if the
+// day is out of range or if a generator is already registered for the day.
+func (r *Registry) RegisterGenerator(day int, g Generator) {
+	if g == nil {
+		panic("solver: RegisterGenerator called with a nil generator")
+	}
+	if day < 1 || day > 25 {
+		panic(fmt.Sprintf("solver: day must be between 1 and 25 inclusive (got %d)", day))
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, dup := r.generators[day]; dup {
+		panic(fmt.Sprintf("solver: RegisterGenerator called twice for day %d", day))
+	}
+	r.generators[day] = g
+}
+
+// LookupGenerator returns the generator registered for the given day along
+// with a boolean value indicating if such a generator exists.
+func (r *Registry) LookupGenerator(day int) (Generator, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	g, ok := r.generators[day]
+	return g, ok
+}
+
+// GeneratorDays returns the days that have a registered generator in
+// ascending order.
+func (r *Registry) GeneratorDays() []int {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	days := make([]int, 0, len(r.generators))
+	for day := range r.generators {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days
+}
+
+// RegisterGenerator adds a Generator for a day to the DefaultRegistry. It is
+// intended to be called from the init function of a puzzle package.
+func RegisterGenerator(day int, g Generator) {
+	DefaultRegistry.RegisterGenerator(day, g)
+}
+
+// LookupGenerator returns the generator registered in the DefaultRegistry for
+// the given day.
+func LookupGenerator(day int) (Generator, bool) {
+	return DefaultRegistry.LookupGenerator(day)
+}
+
+// GeneratorDays returns the days that have a generator registered in the
+// DefaultRegistry.
+func GeneratorDays() []int {
+	return DefaultRegistry.GeneratorDays()
+}
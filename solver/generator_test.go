@@ -0,0 +1,75 @@
+package solver_test
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func generateNumbers(w io.Writer, rng *rand.Rand, size int) error {
+	for i := 0; i < size; i++ {
+		if _, err := fmt.Fprintln(w, rng.Intn(100)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRegistry_LookupGenerator(t *testing.T) {
+	t.Parallel()
+	r := solver.NewRegistry()
+	r.RegisterGenerator(11, generateNumbers)
+	r.RegisterGenerator(2, generateNumbers)
+	if _, ok := r.LookupGenerator(2); !ok {
+		t.Error("want registered generator to be found, but it was not")
+	}
+	if _, ok := r.LookupGenerator(3); ok {
+		t.Error("want unregistered generator to not be found, but it was")
+	}
+	want := []int{2, 11}
+	got := r.GeneratorDays()
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRegistry_RegisterGeneratorPanicsOnInvalidGenerator(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		existingDays []int
+		day          int
+		input        solver.Generator
+	}{
+		"Nil generator panics": {
+			day:   1,
+			input: nil,
+		},
+		"Day out of range panics": {
+			day:   26,
+			input: generateNumbers,
+		},
+		"Duplicate day panics": {
+			existingDays: []int{1},
+			day:          1,
+			input:        generateNumbers,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := solver.NewRegistry()
+			for _, day := range tc.existingDays {
+				r.RegisterGenerator(day, generateNumbers)
+			}
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic but did not get one")
+				}
+			}()
+			r.RegisterGenerator(tc.day, tc.input)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package solver
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Names of the phases reported by solvers created with NewPhased.
+const (
+	PhaseParse = "parse"
+	PhaseSolve = "solve"
+)
+
+// Phase records how long one phase of a solution took to run.
+type Phase struct {
+	Name    string
+	Elapsed time.Duration
+}
+
+// PhasedSolver is implemented by solvers that report how long each phase of
+// their solution (e.g. parsing the input and solving the puzzle) takes.
+type PhasedSolver interface {
+	Solver
+	// SolvePhases behaves like Solve and also returns the phases that were
+	// run in order. The phases run before a failure are returned along with
+	// the error.
+	SolvePhases(input io.Reader) (Answer, []Phase, error)
+}
+
+// phasedContextSolver is implemented by the phased solvers in this package so
+// that the runner can record their phases while passing on a context.
+type phasedContextSolver interface {
+	solvePhasesContext(ctx context.Context, input io.Reader) (Answer, []Phase, error)
+}
+
+// phasedSolver adapts a parse function and a solve function to the
+// PhasedSolver and ContextSolver interfaces.
+type phasedSolver[T any] struct {
+	day   int
+	part  int
+	parse func(io.Reader) (T, error)
+	solve func(context.Context, T) (Answer, error)
+}
+
+func (p phasedSolver[T]) Day() int  { return p.day }
+func (p phasedSolver[T]) Part() int { return p.part }
+
+func (p phasedSolver[T]) Solve(input io.Reader) (Answer, error) {
+	answer, _, err := p.SolvePhases(input)
+	return answer, err
+}
+
+func (p phasedSolver[T]) SolveContext(ctx context.Context, input io.Reader) (Answer, error) {
+	answer, _, err := p.solvePhasesContext(ctx, input)
+	return answer, err
+}
+
+func (p phasedSolver[T]) SolvePhases(input io.Reader) (Answer, []Phase, error) {
+	return p.solvePhasesContext(context.Background(), input)
+}
+
+func (p phasedSolver[T]) solvePhasesContext(ctx context.Context, input io.Reader) (Answer, []Phase, error) {
+	start := time.Now()
+	parsed, err := p.parse(input)
+	phases := []Phase{{Name: PhaseParse, Elapsed: time.Since(start)}}
+	if err != nil {
+		return Answer{}, phases, err
+	}
+	if err := ctx.Err(); err != nil {
+		return Answer{}, phases, err
+	}
+	start = time.Now()
+	answer, err := p.solve(ctx, parsed)
+	phases = append(phases, Phase{Name: PhaseSolve, Elapsed: time.Since(start)})
+	return answer, phases, err
+}
+
+func (p phasedSolver[T]) sourceFunc() any {
+	return p.parse
+}
+
+// NewPhased accepts a day, a part, a function that parses a puzzle input into
+// a T and a function that solves that part of the puzzle for a parsed T, and
+// returns a PhasedSolver reporting the time spent in the PhaseParse and
+// PhaseSolve phases.
+func NewPhased[T any](day, part int, parse func(io.Reader) (T, error), solve func(T) (Answer, error)) PhasedSolver {
+	return NewPhasedContext(day, part, parse, func(_ context.Context, parsed T) (Answer, error) {
+		return solve(parsed)
+	})
+}
+
+// NewPhasedContext behaves like NewPhased but accepts a solve function that
+// stops early when its context is cancelled. The returned solver also
+// implements ContextSolver.
+func NewPhasedContext[T any](day, part int, parse func(io.Reader) (T, error), solve func(context.Context, T) (Answer, error)) PhasedSolver {
+	return phasedSolver[T]{day: day, part: part, parse: parse, solve: solve}
+}
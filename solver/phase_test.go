@@ -0,0 +1,86 @@
+package solver_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func readAll(input io.Reader) (string, error) {
+	data, err := io.ReadAll(input)
+	return string(data), err
+}
+
+func countFields(s string) (solver.Answer, error) {
+	return solver.IntAnswer(len(strings.Fields(s))), nil
+}
+
+func phaseNames(phases []solver.Phase) []string {
+	var names []string
+	for _, p := range phases {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestNewPhasedReportsParseAndSolvePhases(t *testing.T) {
+	t.Parallel()
+	s := solver.NewPhased(8, 1, readAll, countFields)
+	answer, phases, err := s.SolvePhases(strings.NewReader("a b c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(solver.IntAnswer(3), answer) {
+		t.Error(cmp.Diff(solver.IntAnswer(3), answer))
+	}
+	want := []string{solver.PhaseParse, solver.PhaseSolve}
+	got := phaseNames(phases)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestNewPhasedWithParseErrorReportsOnlyParsePhase(t *testing.T) {
+	t.Parallel()
+	wantErr := errors.New("bad input")
+	s := solver.NewPhased(8, 1, func(io.Reader) (string, error) { return "", wantErr }, countFields)
+	_, phases, err := s.SolvePhases(strings.NewReader(""))
+	if !errors.Is(err, wantErr) {
+		t.Errorf("want error %v, got %v", wantErr, err)
+	}
+	want := []string{solver.PhaseParse}
+	got := phaseNames(phases)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRunRecordsPhasesOfPhasedSolvers(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input solver.Solver
+		want  []string
+	}{
+		"Phased solver records its phases": {
+			input: solver.NewPhased(8, 1, readAll, countFields),
+			want:  []string{solver.PhaseParse, solver.PhaseSolve},
+		},
+		"Plain solver records no phases": {
+			input: solver.New(8, 1, answerLength),
+			want:  nil,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res := solver.Run(tc.input, []byte("a b"))
+			got := phaseNames(res.Phases)
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// jsonResult is the JSON representation of a Result.
+type jsonResult struct {
+	Day         int             `json:"day"`
+	Part        int             `json:"part"`
+	Value       json.RawMessage `json:"value,omitempty"`
+	Type        string          `json:"type,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	DurationNs  int64           `json:"duration_ns"`
+	Duration    string          `json:"duration"`
+	Phases      []jsonPhase     `json:"phases,omitempty"`
+	InputSHA256 string          `json:"input_sha256,omitempty"`
+}
+
+// jsonPhase is the JSON representation of a Phase.
+type jsonPhase struct {
+	Name       string `json:"name"`
+	DurationNs int64  `json:"duration_ns"`
+	Duration   string `json:"duration"`
+}
+
+// MarshalJSON encodes the result as a JSON object with the fields day, part,
+// value, type, error, duration_ns, duration, phases and input_sha256. The
+// value and type fields are omitted when the solver returned an error, the
+// error field is omitted when it did not and the phases field is omitted when
+// the solver did not report any phases.
+func (r Result) MarshalJSON() ([]byte, error) {
+	jr := jsonResult{
+		Day:         r.Day,
+		Part:        r.Part,
+		DurationNs:  r.Elapsed.Nanoseconds(),
+		Duration:    r.Elapsed.String(),
+		InputSHA256: r.InputSHA256,
+	}
+	for _, p := range r.Phases {
+		jr.Phases = append(jr.Phases, jsonPhase{
+			Name:       p.Name,
+			DurationNs: p.Elapsed.Nanoseconds(),
+			Duration:   p.Elapsed.String(),
+		})
+	}
+	if r.Err != nil {
+		jr.Error = r.Err.Error()
+		return json.Marshal(jr)
+	}
+	if r.Answer.Value != nil {
+		value, err := json.Marshal(r.Answer.Value)
+		if err != nil {
+			return nil, err
+		}
+		jr.Value = value
+		jr.Type = r.Answer.Type()
+	}
+	return json.Marshal(jr)
+}
+
+// UnmarshalJSON decodes a result produced by MarshalJSON. Int and string
+// answer values are restored to their original types. An error is returned if
+// the data is not a valid result or if the value does not match its type.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var jr jsonResult
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return err
+	}
+	res := Result{
+		Day:         jr.Day,
+		Part:        jr.Part,
+		Elapsed:     time.Duration(jr.DurationNs),
+		InputSHA256: jr.InputSHA256,
+	}
+	for _, p := range jr.Phases {
+		res.Phases = append(res.Phases, Phase{Name: p.Name, Elapsed: time.Duration(p.DurationNs)})
+	}
+	if jr.Error != "" {
+		res.Err = errors.New(jr.Error)
+	}
+	if len(jr.Value) > 0 {
+		value, err := decodeValue(jr.Type, jr.Value)
+		if err != nil {
+			return fmt.Errorf("day %d, part %d: %w", jr.Day, jr.Part, err)
+		}
+		res.Answer = Answer{Value: value}
+	}
+	*r = res
+	return nil
+}
+
+// decodeValue decodes a raw JSON answer value according to its type name.
+func decodeValue(typ string, raw json.RawMessage) (any, error) {
+	switch typ {
+	case "int":
+		n, err := strconv.Atoi(string(bytes.TrimSpace(raw)))
+		if err != nil {
+			return nil, fmt.Errorf("value of type int must be a valid integer (got %s)", raw)
+		}
+		return n, nil
+	case "string":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("value of type string must be a JSON string (got %s)", raw)
+		}
+		return s, nil
+	default:
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
@@ -0,0 +1,96 @@
+package solver_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/aculclasure/aoc2022/textio"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResult_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input solver.Result
+		want  string
+	}{
+		"Int answer is encoded with its type": {
+			input: solver.Result{Day: 1, Part: 2, Answer: solver.IntAnswer(42), Elapsed: time.Millisecond, InputSHA256: "abc"},
+			want:  `{"day":1,"part":2,"value":42,"type":"int","duration_ns":1000000,"duration":"1ms","input_sha256":"abc"}`,
+		},
+		"String answer is encoded with its type": {
+			input: solver.Result{Day: 5, Part: 1, Answer: solver.StringAnswer("CMZ")},
+			want:  `{"day":5,"part":1,"value":"CMZ","type":"string","duration_ns":0,"duration":"0s"}`,
+		},
+		"Phases are encoded with their durations": {
+			input: solver.Result{Day: 8, Part: 2, Answer: solver.IntAnswer(8), Phases: []solver.Phase{
+				{Name: solver.PhaseParse, Elapsed: time.Microsecond},
+				{Name: solver.PhaseSolve, Elapsed: 2 * time.Microsecond},
+			}},
+			want: `{"day":8,"part":2,"value":8,"type":"int","duration_ns":0,"duration":"0s","phases":[{"name":"parse","duration_ns":1000,"duration":"1µs"},{"name":"solve","duration_ns":2000,"duration":"2µs"}]}`,
+		},
+		"Error is encoded without a value": {
+			input: solver.Result{Day: 7, Part: 1, Answer: solver.IntAnswer(1), Err: errors.New("bad input")},
+			want:  `{"day":7,"part":1,"error":"bad input","duration_ns":0,"duration":"0s"}`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := json.Marshal(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, string(got)) {
+				t.Error(cmp.Diff(tc.want, string(got)))
+			}
+		})
+	}
+}
+
+func TestResult_UnmarshalJSONRestoresAnswerType(t *testing.T) {
+	t.Parallel()
+	want := []solver.Result{
+		{Day: 1, Part: 1, Answer: solver.IntAnswer(13237873355), Elapsed: time.Second, InputSHA256: "abc"},
+		{Day: 1, Part: 2, Answer: solver.StringAnswer("#..#\n.##.")},
+		{Day: 8, Part: 1, Answer: solver.IntAnswer(21), Phases: []solver.Phase{{Name: solver.PhaseParse, Elapsed: time.Millisecond}}},
+	}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []solver.Result
+	err = json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRunRecordsInputChecksum(t *testing.T) {
+	t.Parallel()
+	got := solver.Run(solver.New(1, 1, answerLength), []byte("abc"))
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if want != got.InputSHA256 {
+		t.Errorf("want checksum %s, got %s", want, got.InputSHA256)
+	}
+}
+
+func TestRunNamedReportsInputNameInParseErrors(t *testing.T) {
+	t.Parallel()
+	s := solver.New(1, 1, func(input io.Reader) (solver.Answer, error) {
+		scn := textio.NewScanner(input)
+		scn.Scan()
+		return solver.Answer{}, scn.Error(errors.New("bad value"))
+	})
+	got := solver.RunNamed(s, "day01.txt", []byte("abc\n"))
+	want := "day01.txt:1:1: bad value"
+	if got.Err == nil || want != got.Err.Error() {
+		t.Errorf("want error %q, got %v", want, got.Err)
+	}
+}
@@ -0,0 +1,164 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aculclasure/aoc2022/textio"
+)
+
+// Result represents the outcome of running a solver against a puzzle input.
+type Result struct {
+	Day     int
+	Part    int
+	Answer  Answer
+	Err     error
+	Elapsed time.Duration
+	// Phases holds the time spent in each phase of the solution. It is only
+	// set for solvers implementing PhasedSolver.
+	Phases []Phase
+	// InputSHA256 is the hex-encoded SHA-256 checksum of the puzzle input.
+	InputSHA256 string
+}
+
+// Job pairs a solver with the contents of the puzzle input it should be run
+// against.
+type Job struct {
+	Solver Solver
+	// Name is the file name of the input used in parse errors. It may be
+	// empty.
+	Name  string
+	Input []byte
+}
+
+// Run accepts a Solver and the contents of a puzzle input, runs the solver
+// against the input and returns the Result. The phases of solvers that
+// implement PhasedSolver are recorded in the Result.
+func Run(s Solver, input []byte) Result {
+	return RunNamed(s, "", input)
+}
+
+// RunNamed behaves like Run but also accepts the file name of the input, which
+// is reported in any *textio.ParseError returned by the solver.
+func RunNamed(s Solver, name string, input []byte) Result {
+	return RunContext(context.Background(), s, name, input)
+}
+
+// RunContext behaves like RunNamed but passes ctx on to solvers that implement
+// ContextSolver, so that they stop with ctx.Err() once ctx is cancelled. Other
+// solvers run to completion. The solver is not run at all if ctx is already
+// cancelled. A solver that panics fails with a PanicError.
+func RunContext(ctx context.Context, s Solver, name string, input []byte) Result {
+	r := textio.Named(bytes.NewReader(input), name)
+	var (
+		answer Answer
+		phases []Phase
+		err    error
+	)
+	start := time.Now()
+	err = ctx.Err()
+	if err == nil {
+		answer, phases, err = runSolver(ctx, s, r)
+	}
+	elapsed := time.Since(start)
+	return Result{
+		Day:         s.Day(),
+		Part:        s.Part(),
+		Answer:      answer,
+		Err:         err,
+		Elapsed:     elapsed,
+		Phases:      phases,
+		InputSHA256: Checksum(input),
+	}
+}
+
+// runSolver solves the input with s, passing ctx on to solvers that implement
+// ContextSolver and recording the phases of solvers that implement
+// PhasedSolver.
+func runSolver(ctx context.Context, s Solver, input io.Reader) (answer Answer, phases []Phase, err error) {
+	defer recoverPanic(&err)
+	switch ss := s.(type) {
+	case phasedContextSolver:
+		return ss.solvePhasesContext(ctx, input)
+	case PhasedSolver:
+		return ss.SolvePhases(input)
+	case ContextSolver:
+		answer, err = ss.SolveContext(ctx, input)
+	default:
+		answer, err = s.Solve(input)
+	}
+	return answer, nil, err
+}
+
+// solve behaves like s.Solve but reports a panic in the solver as a
+// PanicError.
+func solve(s Solver, input io.Reader) (answer Answer, err error) {
+	defer recoverPanic(&err)
+	return s.Solve(input)
+}
+
+// PanicError is the error reported for a solver that panicked.
+type PanicError struct {
+	// Value is the value the solver panicked with.
+	Value any
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("solver panicked: %v", e.Value)
+}
+
+// recoverPanic recovers from a panic in a solver and stores it in *err as a
+// PanicError. It must be called directly by a deferred statement.
+func recoverPanic(err *error) {
+	if v := recover(); v != nil {
+		*err = PanicError{Value: v}
+	}
+}
+
+// Checksum returns the hex-encoded SHA-256 checksum of a puzzle input.
+func Checksum(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
+
+// RunAll accepts a slice of jobs and the maximum number of jobs to run at the
+// same time, runs every job and returns the results in the same order as the
+// jobs. A workers value smaller than 1 runs the jobs one at a time.
+func RunAll(jobs []Job, workers int) []Result {
+	return RunAllContext(context.Background(), jobs, workers)
+}
+
+// RunAllContext behaves like RunAll but runs each job with RunContext. Once
+// ctx is cancelled the jobs that have not started yet fail with ctx.Err().
+func RunAllContext(ctx context.Context, jobs []Job, workers int) []Result {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	results := make([]Result, len(jobs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = RunContext(ctx, jobs[i].Solver, jobs[i].Name, jobs[i].Input)
+			}
+		}()
+	}
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
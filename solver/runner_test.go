@@ -0,0 +1,126 @@
+package solver_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunReturnsAnswerAndError(t *testing.T) {
+	t.Parallel()
+	wantErr := errors.New("broken")
+	s := solver.New(2, 1, func(io.Reader) (solver.Answer, error) {
+		return solver.IntAnswer(7), wantErr
+	})
+	got := solver.Run(s, nil)
+	if got.Day != 2 || got.Part != 1 {
+		t.Errorf("want day 2, part 1, got day %d, part %d", got.Day, got.Part)
+	}
+	if !cmp.Equal(solver.IntAnswer(7), got.Answer) {
+		t.Error(cmp.Diff(solver.IntAnswer(7), got.Answer))
+	}
+	if !errors.Is(got.Err, wantErr) {
+		t.Errorf("want error %v, got %v", wantErr, got.Err)
+	}
+}
+
+func TestRunAllReturnsResultsInJobOrder(t *testing.T) {
+	t.Parallel()
+	var jobs []solver.Job
+	for day := 1; day <= 10; day++ {
+		jobs = append(jobs, solver.Job{
+			Solver: solver.New(day, 1, answerLength),
+			Input:  make([]byte, day),
+		})
+	}
+	results := solver.RunAll(jobs, 3)
+	if len(results) != len(jobs) {
+		t.Fatalf("want %d results, got %d", len(jobs), len(results))
+	}
+	for i, res := range results {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		want := solver.IntAnswer(i + 1)
+		if res.Day != i+1 || !cmp.Equal(want, res.Answer) {
+			t.Errorf("result %d: want day %d with answer %v, got day %d with answer %v", i, i+1, want, res.Day, res.Answer)
+		}
+	}
+}
+
+func TestRunAllRunsAtMostWorkersJobsAtOnce(t *testing.T) {
+	t.Parallel()
+	const workers = 2
+	var (
+		mtx                 sync.Mutex
+		running, maxRunning int
+		release             = make(chan struct{})
+	)
+	s := solver.New(1, 1, func(io.Reader) (solver.Answer, error) {
+		mtx.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mtx.Unlock()
+		<-release
+		mtx.Lock()
+		running--
+		mtx.Unlock()
+		return solver.Answer{}, nil
+	})
+	jobs := make([]solver.Job, 6)
+	for i := range jobs {
+		jobs[i] = solver.Job{Solver: s}
+	}
+	go func() {
+		for range jobs {
+			release <- struct{}{}
+		}
+	}()
+	solver.RunAll(jobs, workers)
+	if maxRunning > workers {
+		t.Errorf("want at most %d jobs running at once, got %d", workers, maxRunning)
+	}
+}
+
+func TestRunnersReportPanickingSolverAsPanicError(t *testing.T) {
+	t.Parallel()
+	s := solver.NewPhased(4, 1, func(io.Reader) (int, error) {
+		panic("boom")
+	}, func(int) (solver.Answer, error) {
+		return solver.Answer{}, nil
+	})
+	testCases := map[string]func() error{
+		"Run returns a PanicError": func() error {
+			return solver.Run(s, nil).Err
+		},
+		"RunAll returns a PanicError for the job": func() error {
+			return solver.RunAll([]solver.Job{{Solver: s}}, 1)[0].Err
+		},
+		"Verify returns a PanicError": func() error {
+			return solver.Verify(s, strings.NewReader(""), nil).Err
+		},
+		"Bench returns a PanicError": func() error {
+			_, err := solver.Bench(s, nil, 1)
+			return err
+		},
+	}
+	for name, run := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var panicErr solver.PanicError
+			err := run()
+			if !errors.As(err, &panicErr) {
+				t.Fatalf("want a solver.PanicError, got %v", err)
+			}
+			if panicErr.Value != "boom" {
+				t.Errorf("want panic value %q, got %v", "boom", panicErr.Value)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Package solver provides a common interface for the puzzle solutions in this
+// module along with a registry that the puzzle packages register their
+// solutions into. This lets tooling, tests and the aoc command enumerate and
+// run every solution uniformly.
+package solver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"unicode"
+)
+
+// Answer represents the answer to one part of a puzzle. The Value field holds
+// either an int or a string.
+type Answer struct {
+	Value any
+}
+
+// IntAnswer accepts an int and returns it as an Answer.
+func IntAnswer(n int) Answer {
+	return Answer{Value: n}
+}
+
+// StringAnswer accepts a string and returns it as an Answer.
+func StringAnswer(s string) Answer {
+	return Answer{Value: s}
+}
+
+// String returns the answer formatted as a string.
+func (a Answer) String() string {
+	return fmt.Sprint(a.Value)
+}
+
+// Type returns the name of the type of the answer's value (e.g. "int" or
+// "string"). An empty string is returned if the answer has no value.
+func (a Answer) Type() string {
+	if a.Value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", a.Value)
+}
+
+// Solver represents the solution to one part of a puzzle.
+type Solver interface {
+	// Day returns the puzzle day (1-25) that the solver solves.
+	Day() int
+	// Part returns the puzzle part (1 or 2) that the solver solves.
+	Part() int
+	// Solve accepts an io.Reader pointing to a puzzle input and returns the
+	// answer for the puzzle part.
+	Solve(input io.Reader) (Answer, error)
+}
+
+// Func represents a function that solves one part of a puzzle.
+type Func func(input io.Reader) (Answer, error)
+
+// funcSolver adapts a Func to the Solver interface.
+type funcSolver struct {
+	day  int
+	part int
+	fn   Func
+}
+
+func (f funcSolver) Day() int  { return f.day }
+func (f funcSolver) Part() int { return f.part }
+
+func (f funcSolver) Solve(input io.Reader) (Answer, error) {
+	return f.fn(input)
+}
+
+// New accepts a day, a part and a Func that solves that part of the puzzle for
+// the given day and returns a Solver.
+func New(day, part int, fn Func) Solver {
+	return funcSolver{day: day, part: part, fn: fn}
+}
+
+// ErrEmptyInput is returned by solvers whose puzzle input is empty or only
+// contains whitespace.
+var ErrEmptyInput = errors.New("puzzle input must be non-empty")
+
+// NonEmpty accepts an io.Reader pointing to a puzzle input and returns an
+// io.Reader that yields the same input. ErrEmptyInput is returned if the input
+// is empty or only contains whitespace. Solvers whose answer would otherwise be
+// a meaningless zero value for an empty input use it to fail instead.
+func NonEmpty(input io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(input)
+	var leading []byte
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return nil, ErrEmptyInput
+		}
+		if err != nil {
+			return nil, err
+		}
+		leading = append(leading, b)
+		if !unicode.IsSpace(rune(b)) {
+			return io.MultiReader(bytes.NewReader(leading), br), nil
+		}
+	}
+}
+
+// Registry holds a set of solvers keyed by day and part along with the input
+// generators for each day.
+type Registry struct {
+	mtx        sync.RWMutex
+	solvers    map[key]Solver
+	generators map[int]Generator
+}
+
+type key struct {
+	day  int
+	part int
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		solvers:    make(map[key]Solver),
+		generators: make(map[int]Generator),
+	}
+}
+
+// Register accepts a Solver and adds it to the registry. It panics if the
+// solver is nil, if its day or part is out of range, or if a solver is already
+// registered for the same day and part.
+func (r *Registry) Register(s Solver) {
+	if s == nil {
+		panic("solver: Register called with a nil solver")
+	}
+	day, part := s.Day(), s.Part()
+	if day < 1 || day > 25 {
+		panic(fmt.Sprintf("solver: day must be between 1 and 25 inclusive (got %d)", day))
+	}
+	if part != 1 && part != 2 {
+		panic(fmt.Sprintf("solver: part must be 1 or 2 (got %d)", part))
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	k := key{day: day, part: part}
+	if _, dup := r.solvers[k]; dup {
+		panic(fmt.Sprintf("solver: Register called twice for day %d, part %d", day, part))
+	}
+	r.solvers[k] = s
+}
+
+// Lookup returns the solver registered for the given day and part along with
+// a boolean value indicating if such a solver exists.
+func (r *Registry) Lookup(day, part int) (Solver, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	s, ok := r.solvers[key{day: day, part: part}]
+	return s, ok
+}
+
+// Day returns the solvers registered for the given day ordered by part. A nil
+// slice is returned if no solvers are registered for the day.
+func (r *Registry) Day(day int) []Solver {
+	var solvers []Solver
+	for _, s := range r.All() {
+		if s.Day() == day {
+			solvers = append(solvers, s)
+		}
+	}
+	return solvers
+}
+
+// All returns every registered solver ordered by day and then by part.
+func (r *Registry) All() []Solver {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	solvers := make([]Solver, 0, len(r.solvers))
+	for _, s := range r.solvers {
+		solvers = append(solvers, s)
+	}
+	sort.Slice(solvers, func(i, j int) bool {
+		if solvers[i].Day() != solvers[j].Day() {
+			return solvers[i].Day() < solvers[j].Day()
+		}
+		return solvers[i].Part() < solvers[j].Part()
+	})
+	return solvers
+}
+
+// DefaultRegistry is the registry that the puzzle packages in this module
+// register their solvers into.
+var DefaultRegistry = NewRegistry()
+
+// Register adds a Solver to the DefaultRegistry. It is intended to be called
+// from the init function of a puzzle package.
+func Register(s Solver) {
+	DefaultRegistry.Register(s)
+}
+
+// Lookup returns the solver registered in the DefaultRegistry for the given
+// day and part.
+func Lookup(day, part int) (Solver, bool) {
+	return DefaultRegistry.Lookup(day, part)
+}
+
+// Day returns the solvers registered in the DefaultRegistry for the given day.
+func Day(day int) []Solver {
+	return DefaultRegistry.Day(day)
+}
+
+// All returns every solver registered in the DefaultRegistry.
+func All() []Solver {
+	return DefaultRegistry.All()
+}
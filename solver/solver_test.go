@@ -0,0 +1,195 @@
+package solver_test
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/aculclasure/aoc2022/puzzles"
+	"github.com/aculclasure/aoc2022/solver"
+	"github.com/google/go-cmp/cmp"
+)
+
+func answerLength(input io.Reader) (solver.Answer, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.IntAnswer(len(data)), nil
+}
+
+func TestNewReturnsSolverThatCallsFunc(t *testing.T) {
+	t.Parallel()
+	s := solver.New(3, 2, answerLength)
+	if s.Day() != 3 || s.Part() != 2 {
+		t.Fatalf("want day 3, part 2, got day %d, part %d", s.Day(), s.Part())
+	}
+	got, err := s.Solve(strings.NewReader("abcd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := solver.IntAnswer(4)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRegistry_AllReturnsSolversOrderedByDayAndPart(t *testing.T) {
+	t.Parallel()
+	r := solver.NewRegistry()
+	r.Register(solver.New(10, 2, answerLength))
+	r.Register(solver.New(2, 1, answerLength))
+	r.Register(solver.New(10, 1, answerLength))
+	want := [][2]int{{2, 1}, {10, 1}, {10, 2}}
+	var got [][2]int
+	for _, s := range r.All() {
+		got = append(got, [2]int{s.Day(), s.Part()})
+	}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	t.Parallel()
+	r := solver.NewRegistry()
+	r.Register(solver.New(1, 1, answerLength))
+	if _, ok := r.Lookup(1, 1); !ok {
+		t.Error("want registered solver to be found, but it was not")
+	}
+	if _, ok := r.Lookup(1, 2); ok {
+		t.Error("want unregistered solver to not be found, but it was")
+	}
+}
+
+func TestRegistry_RegisterPanicsOnInvalidSolver(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		existing []solver.Solver
+		input    solver.Solver
+	}{
+		"Nil solver panics": {
+			input: nil,
+		},
+		"Day out of range panics": {
+			input: solver.New(26, 1, answerLength),
+		},
+		"Part out of range panics": {
+			input: solver.New(1, 3, answerLength),
+		},
+		"Duplicate day and part panics": {
+			existing: []solver.Solver{solver.New(1, 1, answerLength)},
+			input:    solver.New(1, 1, answerLength),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := solver.NewRegistry()
+			for _, s := range tc.existing {
+				r.Register(s)
+			}
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic but did not get one")
+				}
+			}()
+			r.Register(tc.input)
+		})
+	}
+}
+
+func TestSolverErrorIsReturnedFromSolve(t *testing.T) {
+	t.Parallel()
+	wantErr := errors.New("bad input")
+	s := solver.New(1, 1, func(io.Reader) (solver.Answer, error) {
+		return solver.Answer{}, wantErr
+	})
+	_, err := s.Solve(strings.NewReader(""))
+	if !errors.Is(err, wantErr) {
+		t.Errorf("want error %v, got %v", wantErr, err)
+	}
+}
+
+func TestNonEmptyReturnsErrEmptyInputForBlankInput(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]string{
+		"Empty input returns ErrEmptyInput":           "",
+		"Newline-only input returns ErrEmptyInput":    "\n\n",
+		"Whitespace-only input returns ErrEmptyInput": " \t\r\n",
+	}
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := solver.NonEmpty(strings.NewReader(input))
+			if !errors.Is(err, solver.ErrEmptyInput) {
+				t.Errorf("want error %v, got %v", solver.ErrEmptyInput, err)
+			}
+		})
+	}
+}
+
+func TestNonEmptyReturnsReaderWithTheWholeInput(t *testing.T) {
+	t.Parallel()
+	want := "\n  A Y\nB X\n"
+	r, err := solver.NonEmpty(strings.NewReader(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want != string(got) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestRegisteredSolversReturnErrEmptyInputForEmptyInput(t *testing.T) {
+	t.Parallel()
+	for _, s := range solver.All() {
+		res := solver.Run(s, []byte("\n"))
+		if !errors.Is(res.Err, solver.ErrEmptyInput) {
+			t.Errorf("day %d, part %d: want error %v, got answer %v and error %v", s.Day(), s.Part(), solver.ErrEmptyInput, res.Answer, res.Err)
+		}
+	}
+}
+
+func TestDefaultRegistryContainsEveryPuzzlePart(t *testing.T) {
+	t.Parallel()
+	for day := 1; day <= 11; day++ {
+		for part := 1; part <= 2; part++ {
+			if _, ok := solver.Lookup(day, part); !ok {
+				t.Errorf("want a solver registered for day %d, part %d", day, part)
+			}
+		}
+	}
+}
+
+func TestSourceFile(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input solver.Solver
+		want  string
+	}{
+		"Solver created with New returns the file defining its func": {
+			input: solver.New(1, 1, answerLength),
+			want:  "solver/solver_test.go",
+		},
+		"Registered solver returns a file in its puzzle package": {
+			input: func() solver.Solver { s, _ := solver.Lookup(9, 2); return s }(),
+			want:  "rope/solve.go",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := solver.SourceFile(tc.input)
+			if !ok {
+				t.Fatal("want a source file but did not get one")
+			}
+			if !strings.HasSuffix(filepath.ToSlash(got), tc.want) {
+				t.Errorf("want a path ending in %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package solver
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// sourceFuncer is implemented by the solvers in this package to expose the
+// function whose source file identifies where the solution is defined.
+type sourceFuncer interface {
+	sourceFunc() any
+}
+
+func (f funcSolver) sourceFunc() any {
+	return f.fn
+}
+
+// SourceFile accepts a Solver created with New or NewPhased and returns the
+// path of the source file defining its (parse) function, as recorded when the
+// binary was built. Binaries built with -trimpath record the path relative to
+// the module's import path instead, which usually does not exist on disk. The
+// returned boolean is false for other Solver implementations or if the file
+// cannot be determined.
+func SourceFile(s Solver) (string, bool) {
+	sf, ok := s.(sourceFuncer)
+	if !ok {
+		return "", false
+	}
+	v := reflect.ValueOf(sf.sourceFunc())
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return "", false
+	}
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return "", false
+	}
+	file, _ := fn.FileLine(fn.Entry())
+	return file, file != ""
+}
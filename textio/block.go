@@ -0,0 +1,80 @@
+package textio
+
+import (
+	"io"
+	"strings"
+)
+
+// Block is a paragraph of the input: a run of consecutive non-blank lines.
+type Block struct {
+	Lines []string
+	// Line is the number of the first line of the block, starting at 1.
+	Line     int
+	lineNums []int
+}
+
+// LineNum returns the line number of the i-th line of the block. It differs
+// from b.Line+i when comment lines inside the block were dropped.
+func (b Block) LineNum(i int) int {
+	return b.lineNums[i]
+}
+
+// BlockScanner reads puzzle input made of paragraphs separated by one or more
+// blank lines, such as the calory counts of each elf or the attributes of
+// each monkey. Lines consisting only of whitespace count as blank.
+type BlockScanner struct {
+	scn   *Scanner
+	block Block
+}
+
+// NewBlockScanner returns a BlockScanner reading blocks from r configured with
+// the given options.
+func NewBlockScanner(r io.Reader, opts ...Option) *BlockScanner {
+	return &BlockScanner{scn: NewScanner(r, opts...)}
+}
+
+// Scan advances the BlockScanner to the next block, which is then available
+// through the Block method. It returns false when the end of the input is
+// reached or an error occurs.
+func (s *BlockScanner) Scan() bool {
+	s.block = Block{}
+	for s.scn.Scan() {
+		text := s.scn.Text()
+		if strings.TrimSpace(text) == "" {
+			if len(s.block.Lines) > 0 {
+				return true
+			}
+			continue
+		}
+		if len(s.block.Lines) == 0 {
+			s.block.Line = s.scn.Line()
+		}
+		s.block.Lines = append(s.block.Lines, text)
+		s.block.lineNums = append(s.block.lineNums, s.scn.Line())
+	}
+	return len(s.block.Lines) > 0
+}
+
+// Block returns the current block.
+func (s *BlockScanner) Block() Block {
+	return s.block
+}
+
+// Err returns the first error encountered while reading the input.
+func (s *BlockScanner) Err() error {
+	return s.scn.Err()
+}
+
+// Error accepts the index of a line within the current block and an error
+// found while parsing it and returns the error as a *ParseError positioned at
+// that line, like Scanner.Error.
+func (s *BlockScanner) Error(i int, err error) error {
+	return s.scn.ErrorAt(s.block.LineNum(i), s.block.Lines[i], err)
+}
+
+// Skip accepts the index of a line within the current block and an error
+// found while parsing it and handles it like Scanner.Skip: the error is
+// returned in strict mode and recorded as a diagnostic in lenient mode.
+func (s *BlockScanner) Skip(i int, err error) error {
+	return s.scn.SkipAt(s.block.LineNum(i), s.block.Lines[i], err)
+}
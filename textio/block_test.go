@@ -0,0 +1,99 @@
+package textio_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/textio"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBlockScanner(t *testing.T) {
+	t.Parallel()
+	type block struct {
+		Line  int
+		Lines []string
+	}
+	testCases := map[string]struct {
+		input string
+		opts  []textio.Option
+		want  []block
+	}{
+		"Empty input returns no blocks": {
+			input: "",
+			want:  nil,
+		},
+		"Blocks are split on blank lines": {
+			input: "1000\n2000\n\n4000\n\n5000\n6000\n",
+			want: []block{
+				{Line: 1, Lines: []string{"1000", "2000"}},
+				{Line: 4, Lines: []string{"4000"}},
+				{Line: 6, Lines: []string{"5000", "6000"}},
+			},
+		},
+		"Runs of blank and whitespace-only lines separate a single pair of blocks": {
+			input: "\n\na\n  \n\t\n\nb\nc",
+			want: []block{
+				{Line: 3, Lines: []string{"a"}},
+				{Line: 7, Lines: []string{"b", "c"}},
+			},
+		},
+		"Leading whitespace of lines is kept": {
+			input: "Monkey 0:\n  Test: divisible by 23\n",
+			want: []block{
+				{Line: 1, Lines: []string{"Monkey 0:", "  Test: divisible by 23"}},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			blocks := textio.NewBlockScanner(strings.NewReader(tc.input), tc.opts...)
+			var got []block
+			for blocks.Scan() {
+				b := blocks.Block()
+				got = append(got, block{Line: b.Line, Lines: b.Lines})
+			}
+			if err := blocks.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestBlockScanner_ErrorPositionsErrorAtLineOfBlock(t *testing.T) {
+	t.Parallel()
+	input := "a\n\n# note\nb\n# note\nc\n"
+	blocks := textio.NewBlockScanner(strings.NewReader(input), textio.SkipComments())
+	blocks.Scan()
+	blocks.Scan()
+	err := blocks.Error(1, errors.New("bad value"))
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError, got %v", err)
+	}
+	if pe.Line != 6 || pe.Text != "c" {
+		t.Errorf("want line 6, text c, got line %d, text %s", pe.Line, pe.Text)
+	}
+}
+
+func TestBlockScanner_SkipRecordsDiagnosticInLenientMode(t *testing.T) {
+	t.Parallel()
+	var diags textio.Diagnostics
+	blocks := textio.NewBlockScanner(strings.NewReader("a\nb\n"), textio.Lenient(), textio.WithDiagnostics(&diags))
+	blocks.Scan()
+	if err := blocks.Skip(1, errors.New("bad value")); err != nil {
+		t.Fatalf("want nil error in lenient mode, got %v", err)
+	}
+	var got []string
+	for _, d := range diags.List {
+		got = append(got, d.String())
+	}
+	want := []string{"skipped: 2:1: bad value"}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,116 @@
+package textio
+
+import "errors"
+
+// Severity indicates how a Diagnostic affected the parse.
+type Severity int
+
+const (
+	// Warning indicates a problem that did not change the parsed result, such
+	// as a redundant instruction that was ignored.
+	Warning Severity = iota
+	// Skipped indicates a malformed line that was left out of the parsed
+	// result because parsing was lenient.
+	Skipped
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	if s == Skipped {
+		return "skipped"
+	}
+	return "warning"
+}
+
+// Diagnostic records a problem found in the input that did not stop the
+// parse.
+type Diagnostic struct {
+	Severity Severity
+	Err      *ParseError
+}
+
+// String returns the diagnostic in the form "severity: position: message".
+func (d Diagnostic) String() string {
+	return d.Severity.String() + ": " + d.Err.Error()
+}
+
+// Diagnostics collects the diagnostics reported while parsing an input. The
+// zero value is an empty collector ready to use.
+type Diagnostics struct {
+	List []Diagnostic
+}
+
+// add appends a diagnostic with the given severity to the collector. It does
+// nothing if d is nil.
+func (d *Diagnostics) add(severity Severity, err *ParseError) {
+	if d == nil {
+		return
+	}
+	d.List = append(d.List, Diagnostic{Severity: severity, Err: err})
+}
+
+// Option represents a functional option that can be passed to the parsing
+// functions that read puzzle input through a Scanner.
+type Option func(*Scanner)
+
+// Lenient returns an Option that makes a parser skip invalid lines instead of
+// failing on the first one. By default parsers are strict: the first invalid
+// line stops the parse with a *ParseError giving its position. In lenient mode
+// each invalid line is skipped and recorded as a Skipped diagnostic in the
+// collector given with WithDiagnostics, if any. Parsers document which lines
+// they consider invalid.
+func Lenient() Option {
+	return func(s *Scanner) {
+		s.lenient = true
+	}
+}
+
+// WithDiagnostics accepts a Diagnostics collector and returns an Option that
+// makes a parser record its skipped lines and warnings in d. Lines are only
+// skipped with the Lenient option, while warnings about problems that do not
+// affect the result are recorded in both modes.
+func WithDiagnostics(d *Diagnostics) Option {
+	return func(s *Scanner) {
+		s.diags = d
+	}
+}
+
+// Skip accepts an error found while parsing the current line. In strict mode
+// (the default) the error is returned positioned at the current line, just
+// like Error. In lenient mode the error is recorded as a Skipped diagnostic and
+// nil is returned, telling the caller to skip the line and carry on.
+func (s *Scanner) Skip(err error) error {
+	return s.SkipAt(s.line, s.text, err)
+}
+
+// SkipAt behaves like Skip but positions the error at the given line number,
+// whose text is given by text.
+func (s *Scanner) SkipAt(line int, text string, err error) error {
+	err = s.ErrorAt(line, text, err)
+	if err == nil || !s.lenient {
+		return err
+	}
+	s.diags.add(Skipped, asParseError(err))
+	return nil
+}
+
+// Warn accepts an error describing a problem in the current line that does
+// not affect the parsed result and records it as a Warning diagnostic. Warn
+// does nothing if err is nil or no Diagnostics collector was given.
+func (s *Scanner) Warn(err error) {
+	if err == nil || s.diags == nil {
+		return
+	}
+	err = s.Error(err)
+	s.diags.add(Warning, asParseError(err))
+}
+
+// asParseError returns the *ParseError held by err. Errors returned by
+// Scanner.Error are always a *ParseError.
+func asParseError(err error) *ParseError {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		return pe
+	}
+	return &ParseError{Err: err}
+}
@@ -0,0 +1,112 @@
+package textio
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// bom is the UTF-8 encoded byte order mark that some editors write at the
+// start of a file.
+const bom = "\ufeff"
+
+// TrimTrailingSpace returns an Option that removes trailing whitespace from
+// every line.
+func TrimTrailingSpace() Option {
+	return func(s *Scanner) {
+		s.trimSpace = true
+	}
+}
+
+// SkipComments returns an Option that drops comment lines, which are lines
+// whose first non-whitespace character is '#'. Dropped lines are still counted
+// so that errors report the line number in the original input.
+func SkipComments() Option {
+	return func(s *Scanner) {
+		s.skipComments = true
+	}
+}
+
+// normalize accepts a line read from the input and returns it with the
+// normalizations configured on s applied. ok is false if the line must be
+// dropped.
+func (s *Scanner) normalize(line string) (text string, ok bool) {
+	if s.line == 1 {
+		line = strings.TrimPrefix(line, bom)
+	}
+	if s.trimSpace {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	if s.skipComments && strings.HasPrefix(strings.TrimLeftFunc(line, unicode.IsSpace), "#") {
+		return "", false
+	}
+	return line, true
+}
+
+// scanLines is a bufio.SplitFunc like bufio.ScanLines that also accepts a lone
+// carriage return as a line ending, so that input saved with "\r\n" or "\r"
+// line endings splits into the same lines as input saved with "\n".
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	i := bytes.IndexAny(data, "\r\n")
+	switch {
+	case i < 0:
+		if atEOF {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	case data[i] == '\n':
+		return i + 1, data[:i], nil
+	case i+1 < len(data):
+		if data[i+1] == '\n' {
+			return i + 2, data[:i], nil
+		}
+		return i + 1, data[:i], nil
+	case atEOF:
+		return i + 1, data[:i], nil
+	}
+	// A carriage return at the end of the buffer may be followed by a line
+	// feed, so more data is needed.
+	return 0, nil, nil
+}
+
+// reader is an io.Reader returning the normalized lines of a Scanner.
+type reader struct {
+	scn *Scanner
+	buf []byte
+}
+
+// NewReader accepts an io.Reader and returns an io.Reader that reads the same
+// input normalized: a leading byte order mark is removed and every line ends
+// with "\n", whatever line ending it had. The TrimTrailingSpace and
+// SkipComments options are applied to every line. It is meant for parsers that
+// read their input in one piece rather than line by line with a Scanner, which
+// applies the same normalizations itself.
+func NewReader(r io.Reader, opts ...Option) io.Reader {
+	return &reader{scn: NewScanner(r, opts...)}
+}
+
+// Read implements io.Reader.
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.scn.Scan() {
+			if err := r.scn.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		r.buf = append(r.buf[:0], r.scn.Text()...)
+		r.buf = append(r.buf, '\n')
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Name returns the file name of the underlying reader, if it has one.
+func (r *reader) Name() string {
+	return r.scn.file
+}
@@ -0,0 +1,107 @@
+package textio_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/aculclasure/aoc2022/textio"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScannerNormalizesLines(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input string
+		opts  []textio.Option
+		want  []string
+	}{
+		"Byte order mark is removed from the first line": {
+			input: "\ufeff1000\n2000\n",
+			want:  []string{"1:1000", "2:2000"},
+		},
+		"CRLF line endings are removed": {
+			input: "$ cd /\r\n$ ls\r\n",
+			want:  []string{"1:$ cd /", "2:$ ls"},
+		},
+		"CR line endings split lines": {
+			input: "a\rb\r\rc",
+			want:  []string{"1:a", "2:b", "3:", "4:c"},
+		},
+		"Trailing whitespace is kept by default": {
+			input: "a \t\nb\n",
+			want:  []string{"1:a \t", "2:b"},
+		},
+		"Trailing whitespace is trimmed when requested": {
+			input: "a \t\r\n  b  \n",
+			opts:  []textio.Option{textio.TrimTrailingSpace()},
+			want:  []string{"1:a", "2:  b"},
+		},
+		"Comment lines are kept by default": {
+			input: "# note\na\n",
+			want:  []string{"1:# note", "2:a"},
+		},
+		"Comment lines are dropped when requested without renumbering lines": {
+			input: "# note\na\n  # indented note\nb # not a comment\n",
+			opts:  []textio.Option{textio.SkipComments()},
+			want:  []string{"2:a", "4:b # not a comment"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scn := textio.NewScanner(strings.NewReader(tc.input), tc.opts...)
+			var got []string
+			for scn.Scan() {
+				got = append(got, fmt.Sprintf("%d:%s", scn.Line(), scn.Text()))
+			}
+			if err := scn.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestScannerSplitsCRLFAcrossReads(t *testing.T) {
+	t.Parallel()
+	scn := textio.NewScanner(iotest.OneByteReader(strings.NewReader("a\r\nb\r\n")))
+	var got []string
+	for scn.Scan() {
+		got = append(got, scn.Text())
+	}
+	want := []string{"a", "b"}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	t.Parallel()
+	input := "\ufeff# header\r\nabc  \r\n\r\nxyz"
+	r := textio.NewReader(strings.NewReader(input), textio.TrimTrailingSpace(), textio.SkipComments())
+	got, err := io.ReadAll(iotest.OneByteReader(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "abc\n\nxyz\n"
+	if want != string(got) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestNewReaderKeepsInputName(t *testing.T) {
+	t.Parallel()
+	r := textio.NewReader(textio.Named(strings.NewReader("a\n"), "in.txt"))
+	scn := textio.NewScanner(r)
+	scn.Scan()
+	want := "in.txt:1:1: bad value"
+	got := scn.Error(errors.New("bad value")).Error()
+	if want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,259 @@
+// Package textio provides helpers shared by the puzzle parsers for reading
+// line-oriented puzzle input and reporting where in the input a problem was
+// found.
+package textio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+const (
+	// DefaultMaxLineLength is the default length in bytes of the longest line
+	// a Scanner can read. It is effectively unlimited, so that a line is only
+	// limited by the memory available to hold it.
+	DefaultMaxLineLength = math.MaxInt
+
+	// initialBufferSize is the size of the buffer a Scanner starts with. The
+	// buffer grows as needed up to the maximum line length.
+	initialBufferSize = 64 * 1024
+)
+
+// ParseError records a problem parsing a piece of puzzle input along with its
+// position in the input.
+type ParseError struct {
+	// File is the name of the input file. It is empty if the input is not a
+	// named file.
+	File string
+	// Line is the line number, starting at 1. It is 0 if the line is unknown.
+	Line int
+	// Column is the byte offset of the offending text within the line,
+	// starting at 1. It is 0 if the column is unknown.
+	Column int
+	// Text is the offending text.
+	Text string
+	Err  error
+}
+
+// Error returns the error message prefixed with the position in the form
+// "file:line:column", leaving out the parts of the position that are unknown.
+func (e *ParseError) Error() string {
+	pos := e.File
+	switch {
+	case e.Line > 0:
+		if pos != "" {
+			pos += ":"
+		}
+		pos += strconv.Itoa(e.Line)
+		if e.Column > 0 {
+			pos += ":" + strconv.Itoa(e.Column)
+		}
+	case e.Column > 0:
+		if pos != "" {
+			pos += ": "
+		}
+		pos += "column " + strconv.Itoa(e.Column)
+	}
+	if pos == "" {
+		return e.Err.Error()
+	}
+	return pos + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError accepts the column and text of an offending field within a line
+// and the error describing the problem and returns a *ParseError. It is meant
+// for functions that parse a single line and do not know the line number,
+// which is filled in by Scanner.Error.
+func FieldError(column int, text string, err error) error {
+	return &ParseError{Column: column, Text: text, Err: err}
+}
+
+// namer is implemented by readers that have a name, such as *os.File.
+type namer interface {
+	Name() string
+}
+
+// namedReader is an io.Reader with a name.
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+// Name returns the name of the reader.
+func (r namedReader) Name() string {
+	return r.name
+}
+
+// Named accepts an io.Reader and the name of the file it reads from and
+// returns an io.Reader whose name is used by NewScanner as the file name in
+// errors. r is returned unchanged if name is empty.
+func Named(r io.Reader, name string) io.Reader {
+	if name == "" {
+		return r
+	}
+	return namedReader{Reader: r, name: name}
+}
+
+// Scanner reads puzzle input line by line like a bufio.Scanner while keeping
+// track of the current line number so that errors can report their position.
+//
+// The lines are normalized as they are read: a leading byte order mark is
+// removed and "\r\n" and "\r" line endings are accepted along with "\n".
+type Scanner struct {
+	scn          *bufio.Scanner
+	file         string
+	line         int
+	text         string
+	lenient      bool
+	diags        *Diagnostics
+	trimSpace    bool
+	skipComments bool
+	// maxLineLength is the length in bytes of the longest line that can be
+	// read, including its line ending.
+	maxLineLength int
+}
+
+// WithMaxLineLength accepts the length in bytes of the longest line that may
+// be read, including its line ending, and returns an Option that limits lines
+// to that length. Reading a longer line stops the Scanner and makes Err return
+// an error wrapping bufio.ErrTooLong. Values smaller than 1 keep the default of
+// DefaultMaxLineLength.
+func WithMaxLineLength(n int) Option {
+	return func(s *Scanner) {
+		if n > 0 {
+			s.maxLineLength = n
+		}
+	}
+}
+
+// NewScanner returns a Scanner reading lines from r configured with the given
+// options. If r has a Name method (like *os.File) the name is used as the file
+// name in errors.
+func NewScanner(r io.Reader, opts ...Option) *Scanner {
+	s := &Scanner{scn: bufio.NewScanner(r), maxLineLength: DefaultMaxLineLength}
+	if n, ok := r.(namer); ok {
+		s.file = n.Name()
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	s.scn.Split(scanLines)
+	// The maximum line length of a bufio.Scanner is never smaller than the
+	// capacity of its buffer.
+	size := initialBufferSize
+	if s.maxLineLength < size {
+		size = s.maxLineLength
+	}
+	s.scn.Buffer(make([]byte, 0, size), s.maxLineLength)
+	return s
+}
+
+// Scan advances the Scanner to the next line, which is then available through
+// the Text method. It returns false when the end of the input is reached or an
+// error occurs.
+func (s *Scanner) Scan() bool {
+	for s.scn.Scan() {
+		s.line++
+		text, ok := s.normalize(s.scn.Text())
+		if !ok {
+			continue
+		}
+		s.text = text
+		return true
+	}
+	return false
+}
+
+// Text returns the current line without its line ending.
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Line returns the number of the current line, starting at 1.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Err returns the first error encountered while reading the input, positioned
+// at the line that could not be read.
+func (s *Scanner) Err() error {
+	err := s.scn.Err()
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, bufio.ErrTooLong) {
+		err = fmt.Errorf("line is longer than the maximum of %d bytes: %w", s.maxLineLength, err)
+	}
+	return &ParseError{File: s.file, Line: s.line + 1, Err: err}
+}
+
+// Error accepts an error found while parsing the current line and returns it
+// as a *ParseError positioned at the current line. If err already holds a
+// *ParseError (e.g. one returned by FieldError) its column and text are kept
+// and its file and line are filled in. Otherwise (including when err wraps a
+// *ParseError) the whole line is recorded as the offending text starting at
+// column 1. Error returns nil if err is nil.
+func (s *Scanner) Error(err error) error {
+	return s.ErrorAt(s.line, s.text, err)
+}
+
+// ErrorAt behaves like Error but positions the error at the given line
+// number, whose text is given by text. It is used for errors that are only
+// found after the line that caused them has been scanned.
+func (s *Scanner) ErrorAt(line int, text string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if pe, ok := err.(*ParseError); ok {
+		positioned := *pe
+		if positioned.File == "" {
+			positioned.File = s.file
+		}
+		if positioned.Line == 0 {
+			positioned.Line = line
+		}
+		return &positioned
+	}
+	return &ParseError{File: s.file, Line: line, Column: 1, Text: text, Err: err}
+}
+
+// Field is a whitespace-separated field of a line along with the column it
+// starts at.
+type Field struct {
+	Text string
+	// Column is the byte offset of the field within the line, starting at 1.
+	Column int
+}
+
+// Fields splits line around runs of whitespace like strings.Fields and
+// returns the fields along with their columns.
+func Fields(line string) []Field {
+	var fields []Field
+	start := -1
+	for i, r := range line {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fields = append(fields, Field{Text: line[start:i], Column: start + 1})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, Field{Text: line[start:], Column: start + 1})
+	}
+	return fields
+}
@@ -0,0 +1,261 @@
+package textio_test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aculclasure/aoc2022/textio"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseError_Error(t *testing.T) {
+	t.Parallel()
+	errBad := errors.New("bad value")
+	testCases := map[string]struct {
+		input *textio.ParseError
+		want  string
+	}{
+		"Error with file, line and column prefixes the full position": {
+			input: &textio.ParseError{File: "day05.txt", Line: 12, Column: 6, Err: errBad},
+			want:  "day05.txt:12:6: bad value",
+		},
+		"Error without a file prefixes line and column": {
+			input: &textio.ParseError{Line: 3, Column: 1, Err: errBad},
+			want:  "3:1: bad value",
+		},
+		"Error without a column prefixes the line": {
+			input: &textio.ParseError{File: "in.txt", Line: 3, Err: errBad},
+			want:  "in.txt:3: bad value",
+		},
+		"Error with only a column prefixes the column": {
+			input: &textio.ParseError{Column: 7, Err: errBad},
+			want:  "column 7: bad value",
+		},
+		"Error without a position returns the message": {
+			input: &textio.ParseError{Err: errBad},
+			want:  "bad value",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.input.Error()
+			if tc.want != got {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input string
+		want  []textio.Field
+	}{
+		"Empty line returns no fields": {
+			input: "",
+			want:  nil,
+		},
+		"Fields separated by runs of whitespace return their columns": {
+			input: "  move 3\tfrom  1 ",
+			want: []textio.Field{
+				{Text: "move", Column: 3},
+				{Text: "3", Column: 8},
+				{Text: "from", Column: 10},
+				{Text: "1", Column: 16},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := textio.Fields(tc.input)
+			if !cmp.Equal(tc.want, got) {
+				t.Error(cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestScanner_ErrorPositionsErrorAtCurrentLine(t *testing.T) {
+	t.Parallel()
+	errBad := errors.New("bad value")
+	testCases := map[string]struct {
+		input error
+		want  textio.ParseError
+	}{
+		"Plain error records the whole line from column 1": {
+			input: errBad,
+			want:  textio.ParseError{File: "in.txt", Line: 2, Column: 1, Text: "b x", Err: errBad},
+		},
+		"Field error keeps its column and text": {
+			input: textio.FieldError(3, "x", errBad),
+			want:  textio.ParseError{File: "in.txt", Line: 2, Column: 3, Text: "x", Err: errBad},
+		},
+		"Wrapped field error is treated as a plain error": {
+			input: fmt.Errorf("context: %w", textio.FieldError(3, "x", errBad)),
+			want:  textio.ParseError{File: "in.txt", Line: 2, Column: 1, Text: "b x"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scn := textio.NewScanner(textio.Named(strings.NewReader("a\nb x\nc\n"), "in.txt"))
+			scn.Scan()
+			scn.Scan()
+			err := scn.Error(tc.input)
+			var got *textio.ParseError
+			if !errors.As(err, &got) {
+				t.Fatalf("want a *textio.ParseError, got %v", err)
+			}
+			if !errors.Is(err, errBad) {
+				t.Errorf("want error to wrap %v, got %v", errBad, err)
+			}
+			want := tc.want
+			if want.Err == nil {
+				want.Err = tc.input
+			}
+			if !cmp.Equal(want, *got, cmp.Comparer(func(a, b error) bool { return a == b })) {
+				t.Errorf("want %#v, got %#v", want, *got)
+			}
+		})
+	}
+}
+
+func TestScanner_ErrorReturnsNilForNilError(t *testing.T) {
+	t.Parallel()
+	scn := textio.NewScanner(strings.NewReader("a\n"))
+	scn.Scan()
+	if err := scn.Error(nil); err != nil {
+		t.Errorf("want nil error, got %v", err)
+	}
+}
+
+func TestScanner_LineCountsScannedLines(t *testing.T) {
+	t.Parallel()
+	scn := textio.NewScanner(strings.NewReader("a\n\nc"))
+	var got []string
+	for scn.Scan() {
+		got = append(got, fmt.Sprintf("%d:%s", scn.Line(), scn.Text()))
+	}
+	if err := scn.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"1:a", "2:", "3:c"}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestScanner_SkipReturnsErrorInStrictMode(t *testing.T) {
+	t.Parallel()
+	var diags textio.Diagnostics
+	scn := textio.NewScanner(strings.NewReader("a\n"), textio.WithDiagnostics(&diags))
+	scn.Scan()
+	err := scn.Skip(errors.New("bad value"))
+	var pe *textio.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("want a *textio.ParseError, got %v", err)
+	}
+	if len(diags.List) != 0 {
+		t.Errorf("want no diagnostics, got %v", diags.List)
+	}
+}
+
+func TestScanner_SkipAndWarnRecordDiagnosticsInLenientMode(t *testing.T) {
+	t.Parallel()
+	var diags textio.Diagnostics
+	scn := textio.NewScanner(strings.NewReader("a\nb\n"), textio.Lenient(), textio.WithDiagnostics(&diags))
+	scn.Scan()
+	if err := scn.Skip(textio.FieldError(1, "a", errors.New("bad value"))); err != nil {
+		t.Fatalf("want nil error in lenient mode, got %v", err)
+	}
+	scn.Scan()
+	scn.Warn(errors.New("odd value"))
+	scn.Warn(nil)
+	var got []string
+	for _, d := range diags.List {
+		got = append(got, d.String())
+	}
+	want := []string{"skipped: 1:1: bad value", "warning: 2:1: odd value"}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestScanner_SkipWithoutCollectorDropsErrorInLenientMode(t *testing.T) {
+	t.Parallel()
+	scn := textio.NewScanner(strings.NewReader("a\n"), textio.Lenient())
+	scn.Scan()
+	if err := scn.Skip(errors.New("bad value")); err != nil {
+		t.Errorf("want nil error in lenient mode, got %v", err)
+	}
+	scn.Warn(errors.New("odd value"))
+}
+
+func TestScanner_ReadsMultiMegabyteLines(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("x", 8<<20)
+	scn := textio.NewScanner(strings.NewReader("a\n" + long + "\nb\n"))
+	var got []int
+	for scn.Scan() {
+		got = append(got, len(scn.Text()))
+	}
+	if err := scn.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []int{1, len(long), 1}
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestScanner_WithMaxLineLength(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		input   string
+		wantErr bool
+	}{
+		"Line within the limit is read": {
+			input: "a\n123456789\n",
+		},
+		"Line over the limit returns an error": {
+			input:   "a\n1234567890\n",
+			wantErr: true,
+		},
+		"Multi-megabyte line over a limit larger than the initial buffer returns an error": {
+			input:   "a\n" + strings.Repeat("x", 2<<20) + "\n",
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			max := 10
+			if len(tc.input) > 1<<20 {
+				max = 1 << 20
+			}
+			scn := textio.NewScanner(strings.NewReader(tc.input), textio.WithMaxLineLength(max))
+			for scn.Scan() {
+			}
+			err := scn.Err()
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("want error wrapping bufio.ErrTooLong, got %v", err)
+			}
+			var pe *textio.ParseError
+			if !errors.As(err, &pe) || pe.Line != 2 {
+				t.Errorf("want a *textio.ParseError on line 2, got %v", err)
+			}
+			want := fmt.Sprintf("line is longer than the maximum of %d bytes", max)
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("want error containing %q, got %q", want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Package watch detects modifications to a set of files by polling them. It
+// is used by "aoc watch" to re-run a solution whenever its source or puzzle
+// input changes.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"time"
+)
+
+// fileState holds the attributes of a file that are compared to detect a
+// modification.
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// Snapshot records the state of a set of files at a point in time.
+type Snapshot map[string]fileState
+
+// Take accepts a set of file paths and returns a Snapshot of them. Files that
+// do not exist are recorded as missing rather than causing an error. An error
+// is returned if a file cannot be inspected for any other reason.
+func Take(paths []string) (Snapshot, error) {
+	snap := make(Snapshot, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			snap[path] = fileState{}
+		case err != nil:
+			return nil, err
+		default:
+			snap[path] = fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+		}
+	}
+	return snap, nil
+}
+
+// Changed accepts a later Snapshot and returns the sorted paths of the files
+// that were created, removed or modified between the two snapshots.
+func (s Snapshot) Changed(later Snapshot) []string {
+	var changed []string
+	for path, st := range later {
+		if prev := s[path]; prev.exists != st.exists || prev.size != st.size || !prev.modTime.Equal(st.modTime) {
+			changed = append(changed, path)
+		}
+	}
+	for path, st := range s {
+		if _, ok := later[path]; !ok && st.exists {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Poller polls a set of files for modifications.
+type Poller struct {
+	// Paths returns the files to watch. It is called on every poll so that
+	// files added to a watched directory are picked up.
+	Paths func() ([]string, error)
+	// Interval is the time between polls. It must be positive.
+	Interval time.Duration
+	// Quiet is how long the files must go without further modifications
+	// before a change is reported. It keeps a burst of rapid saves from being
+	// reported as several changes.
+	Quiet time.Duration
+}
+
+// Watch polls the files until ctx is cancelled, calling fn with the sorted
+// paths of the modified files once they have been quiet for p.Quiet. Changes
+// that happen while fn is running are reported by a later call. Watch returns
+// ctx.Err() when the context is cancelled or an error if p.Interval is not
+// positive or the files cannot be listed or inspected.
+func (p *Poller) Watch(ctx context.Context, fn func(changed []string)) error {
+	if p.Interval <= 0 {
+		return fmt.Errorf("poll interval must be positive, got %s", p.Interval)
+	}
+	prev, err := p.take()
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	pending := make(map[string]bool)
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			cur, err := p.take()
+			if err != nil {
+				return err
+			}
+			if changed := prev.Changed(cur); len(changed) > 0 {
+				for _, path := range changed {
+					pending[path] = true
+				}
+				lastChange = now
+			}
+			prev = cur
+			if len(pending) == 0 || now.Sub(lastChange) < p.Quiet {
+				continue
+			}
+			changed := make([]string, 0, len(pending))
+			for path := range pending {
+				changed = append(changed, path)
+			}
+			sort.Strings(changed)
+			pending = make(map[string]bool)
+			fn(changed)
+		}
+	}
+}
+
+// take returns a Snapshot of the files currently returned by p.Paths.
+func (p *Poller) take() (Snapshot, error) {
+	paths, err := p.Paths()
+	if err != nil {
+		return nil, err
+	}
+	return Take(paths)
+}
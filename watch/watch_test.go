@@ -0,0 +1,131 @@
+package watch_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aculclasure/aoc2022/watch"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSnapshot_Changed(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	unchanged := filepath.Join(dir, "unchanged.go")
+	modified := filepath.Join(dir, "modified.go")
+	removed := filepath.Join(dir, "removed.go")
+	created := filepath.Join(dir, "created.go")
+	for _, path := range []string{unchanged, modified, removed} {
+		if err := os.WriteFile(path, []byte("package a\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	paths := []string{unchanged, modified, removed, created}
+	before, err := watch.Take(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(modified, []byte("package a // changed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(removed); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(created, []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	after, err := watch.Take(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{created, modified, removed}
+	got := before.Changed(after)
+	if !cmp.Equal(want, got) {
+		t.Error(cmp.Diff(want, got))
+	}
+}
+
+func TestPoller_WatchReportsBurstOfChangesOnce(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := &watch.Poller{
+		Paths:    func() ([]string, error) { return []string{path}, nil },
+		Interval: 5 * time.Millisecond,
+		Quiet:    100 * time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan []string, 10)
+	done := make(chan error)
+	go func() {
+		done <- p.Watch(ctx, func(changed []string) { calls <- changed })
+	}()
+
+	// Each save grows the file so the change is detected even on file
+	// systems with coarse modification times.
+	for i := 1; i <= 5; i++ {
+		if err := os.WriteFile(path, make([]byte, i), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case got := <-calls:
+		want := []string{path}
+		if !cmp.Equal(want, got) {
+			t.Error(cmp.Diff(want, got))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the change to be reported")
+	}
+	select {
+	case got := <-calls:
+		t.Errorf("want a single report for a burst of changes, got another for %v", got)
+	case <-time.After(200 * time.Millisecond):
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("want error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestPoller_WatchReturnsErrorFromPaths(t *testing.T) {
+	t.Parallel()
+	wantErr := errors.New("bad pattern")
+	p := &watch.Poller{
+		Paths:    func() ([]string, error) { return nil, wantErr },
+		Interval: time.Millisecond,
+	}
+	err := p.Watch(context.Background(), func([]string) {})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("want error %v, got %v", wantErr, err)
+	}
+}
+
+func TestPoller_WatchReturnsErrorForNonPositiveInterval(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]time.Duration{
+		"Zero interval returns error":     0,
+		"Negative interval returns error": -time.Second,
+	}
+	for name, interval := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := &watch.Poller{
+				Paths:    func() ([]string, error) { return nil, nil },
+				Interval: interval,
+			}
+			err := p.Watch(context.Background(), func([]string) {})
+			if err == nil {
+				t.Error("expected an error but did not get one")
+			}
+		})
+	}
+}